@@ -14,6 +14,7 @@ import (
 	"github.com/wollac/iota-crypto-demo/pkg/bech32/address"
 	"github.com/wollac/iota-crypto-demo/pkg/bip32path"
 	"github.com/wollac/iota-crypto-demo/pkg/bip39"
+	"github.com/wollac/iota-crypto-demo/pkg/bip39/electrum"
 	"github.com/wollac/iota-crypto-demo/pkg/slip10"
 	"github.com/wollac/iota-crypto-demo/pkg/slip10/eddsa"
 	"github.com/wollac/iota-crypto-demo/pkg/slip10/elliptic"
@@ -30,6 +31,11 @@ var (
 		"english",
 		"language of the mnemonics",
 	)
+	electrumSeed = flag.Bool(
+		"electrum",
+		false,
+		"treat the mnemonic as Electrum seed instead of BIP-39 mnemonic",
+	)
 	passphrase = flag.String(
 		"passphrase",
 		"",
@@ -57,6 +63,10 @@ func main() {
 }
 
 func run() error {
+	if *electrumSeed {
+		return runElectrum()
+	}
+
 	var (
 		err      error
 		entropy  []byte
@@ -94,6 +104,49 @@ func run() error {
 	fmt.Printf(" optional passphrase:\t\"%s\"\n", *passphrase)
 	fmt.Printf(" master seed (%d-byte):\t%x\n", len(seed), seed)
 
+	return deriveKeys(seed, path)
+}
+
+func runElectrum() error {
+	var (
+		err      error
+		mnemonic bip39.Mnemonic
+	)
+
+	if len(*mnemonicString) == 0 {
+		// no mnemonic given, generate
+		mnemonic, err = electrum.NewMnemonic(rand.Reader, electrum.Segwit, strings.ToLower(*language))
+		if err != nil {
+			return fmt.Errorf("failed generating mnemonic: %w", err)
+		}
+	} else {
+		mnemonic = bip39.ParseMnemonic(*mnemonicString)
+	}
+
+	version, err := electrum.DetectVersion(mnemonic)
+	if err != nil {
+		return fmt.Errorf("invalid mnemonic: %w", err)
+	}
+	seed, err := electrum.MnemonicToSeed(mnemonic, *passphrase)
+	if err != nil {
+		return fmt.Errorf("failed deriving seed: %w", err)
+	}
+	path, err := bip32path.ParsePath(*pathString)
+	if err != nil {
+		return fmt.Errorf("invalid path: %w", err)
+	}
+
+	fmt.Println("==> Key Derivation Parameters")
+
+	fmt.Printf(" Electrum version:\t%s\n", version)
+	fmt.Printf(" mnemonic (%d-word):\t%s\n", len(mnemonic), mnemonic)
+	fmt.Printf(" optional passphrase:\t\"%s\"\n", *passphrase)
+	fmt.Printf(" master seed (%d-byte):\t%x\n", len(seed), seed)
+
+	return deriveKeys(seed, path)
+}
+
+func deriveKeys(seed []byte, path bip32path.Path) error {
 	fmt.Println("\n==> Legacy IOTA Seed Derivation (Ledger App)")
 
 	curve := elliptic.Secp256k1()
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"crypto/rand"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/wollac/iota-crypto-demo/pkg/bip39"
+	"github.com/wollac/iota-crypto-demo/pkg/bip39/seedqr"
+	"github.com/wollac/iota-crypto-demo/pkg/qrcode"
+)
+
+var (
+	mnemonic = flag.String(
+		"mnemonic",
+		"",
+		"12 or 24 word English mnemonic; if empty a new random mnemonic is generated",
+	)
+	compact = flag.Bool(
+		"compact",
+		false,
+		"generate a CompactSeedQR instead of a Standard SeedQR",
+	)
+	inverse = flag.Bool(
+		"inverse",
+		false,
+		"invert the colors for terminals with a dark background",
+	)
+	output = flag.String(
+		"png",
+		"",
+		"file to write the QR code as PNG image to; if empty the QR code is printed on the console",
+	)
+)
+
+func main() {
+	flag.Parse()
+
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	var m bip39.Mnemonic
+	if len(*mnemonic) == 0 {
+		var err error
+		m, err = generateMnemonic()
+		if err != nil {
+			return err
+		}
+	} else {
+		m = bip39.ParseMnemonic(*mnemonic)
+	}
+
+	var (
+		q   *qrcode.QRCode
+		err error
+	)
+	if *compact {
+		q, err = seedqr.Compact(m)
+	} else {
+		q, err = seedqr.Standard(m)
+	}
+	if err != nil {
+		return fmt.Errorf("failed encoding mnemonic: %w", err)
+	}
+
+	fmt.Println("==> SeedQR")
+	fmt.Printf(" mnemonic (%d-word):\t%s\n", len(m), m)
+	fmt.Printf(" QR code:\t\tversion %d, %dx%d modules\n", q.Version(), q.Size(), q.Size())
+
+	if len(*output) > 0 {
+		return writePNG(q, *output)
+	}
+	fmt.Println()
+	fmt.Print(q.Terminal(*inverse))
+	return nil
+}
+
+func generateMnemonic() (bip39.Mnemonic, error) {
+	entropy := make([]byte, 32)
+	if _, err := rand.Read(entropy); err != nil {
+		return nil, err
+	}
+	codec, err := bip39.NewCodecForLanguage("english")
+	if err != nil {
+		return nil, err
+	}
+	return codec.EntropyToMnemonic(entropy)
+}
+
+func writePNG(q *qrcode.QRCode, name string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	// use 8 pixels per module to make the code easily scannable
+	if err := q.WritePNG(f, 8); err != nil {
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,9 @@
+// Package wipe provides helpers to overwrite secret data in memory once it is no longer needed.
+package wipe
+
+// Bytes overwrites all bytes of b with zeros.
+func Bytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
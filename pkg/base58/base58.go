@@ -0,0 +1,123 @@
+// Package base58 implements the Base58 and Base58Check encodings as used in Bitcoin.
+package base58
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ChecksumSize is the size, in bytes, of the Base58Check checksum.
+const ChecksumSize = 4
+
+const alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var (
+	// ErrInvalidCharacter reports an attempt to decode a character that is not part of the alphabet.
+	ErrInvalidCharacter = errors.New("invalid character")
+	// ErrInvalidLength reports an attempt to decode a Base58Check string that is too short to contain a checksum.
+	ErrInvalidLength = errors.New("invalid length")
+	// ErrInvalidChecksum reports an attempt to decode a Base58Check string with an invalid checksum.
+	ErrInvalidChecksum = errors.New("invalid checksum")
+)
+
+var (
+	bigRadix = big.NewInt(58)
+	bigZero  = big.NewInt(0)
+	decMap   [256]int8
+)
+
+func init() {
+	for i := range decMap {
+		decMap[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		decMap[alphabet[i]] = int8(i)
+	}
+}
+
+// Encode returns the Base58 encoding of src.
+// Each leading zero byte is encoded as a leading '1'.
+func Encode(src []byte) string {
+	zeros := countLeading(src, 0)
+
+	x := new(big.Int).SetBytes(src)
+	mod := new(big.Int)
+	// log(256)/log(58) < 1.37, so this is an upper bound of the required digits
+	digits := make([]byte, 0, len(src)*137/100+1)
+	for x.Cmp(bigZero) > 0 {
+		x.DivMod(x, bigRadix, mod)
+		digits = append(digits, alphabet[mod.Int64()])
+	}
+
+	var dst strings.Builder
+	dst.Grow(zeros + len(digits))
+	for i := 0; i < zeros; i++ {
+		dst.WriteByte(alphabet[0])
+	}
+	// the digits have been computed with the least significant first
+	for i := len(digits) - 1; i >= 0; i-- {
+		dst.WriteByte(digits[i])
+	}
+	return dst.String()
+}
+
+// Decode returns the bytes represented by the Base58 string s.
+func Decode(s string) ([]byte, error) {
+	zeros := countLeading([]byte(s), alphabet[0])
+
+	x := new(big.Int)
+	digit := new(big.Int)
+	for i := zeros; i < len(s); i++ {
+		d := decMap[s[i]]
+		if d < 0 {
+			return nil, fmt.Errorf("%w at input byte %d", ErrInvalidCharacter, i)
+		}
+		x.Mul(x, bigRadix)
+		x.Add(x, digit.SetInt64(int64(d)))
+	}
+	return append(make([]byte, zeros), x.Bytes()...), nil
+}
+
+// CheckEncode returns the Base58Check encoding of src, i.e. the Base58 encoding of src with its checksum appended.
+func CheckEncode(src []byte) string {
+	buf := make([]byte, 0, len(src)+ChecksumSize)
+	buf = append(buf, src...)
+	buf = append(buf, checksum(src)...)
+	return Encode(buf)
+}
+
+// CheckDecode returns the bytes represented by the Base58Check string s.
+// It returns an error, if s is not a valid Base58 string or the checksum does not match.
+func CheckDecode(s string) ([]byte, error) {
+	buf, err := Decode(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) < ChecksumSize {
+		return nil, ErrInvalidLength
+	}
+	data, sum := buf[:len(buf)-ChecksumSize], buf[len(buf)-ChecksumSize:]
+	if !bytes.Equal(sum, checksum(data)) {
+		return nil, ErrInvalidChecksum
+	}
+	return data, nil
+}
+
+// checksum returns the first four bytes of the double SHA-256 hash of data.
+func checksum(data []byte) []byte {
+	h1 := sha256.Sum256(data)
+	h2 := sha256.Sum256(h1[:])
+	return h2[:ChecksumSize]
+}
+
+func countLeading(b []byte, c byte) int {
+	n := 0
+	for n < len(b) && b[n] == c {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,104 @@
+package base58_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wollac/iota-crypto-demo/pkg/base58"
+)
+
+var encodingTests = []struct {
+	hex string
+	s   string
+}{
+	{"", ""},
+	{"61", "2g"},
+	{"626262", "a3gV"},
+	{"636363", "aPEr"},
+	{"73696d706c792061206c6f6e6720737472696e67", "2cFupjhnEsSn59qHXstmK2ffpLv2"},
+	{"00eb15231dfceb60925886b67d065299925915aeb172c06647", "1NS17iag9jJgTHD1VXjvLCEnZuQ3rJDE9L"},
+	{"516b6fcd0f", "ABnLTmg"},
+	{"bf4f89001e670274dd", "3SEo3LWLoPntC"},
+	{"572e4794", "3EFU7m"},
+	{"ecac89cad93923c02321", "EJDM8drfXA6uyA"},
+	{"10c8511e", "Rt5zm"},
+	{"00000000000000000000", "1111111111"},
+	{"000111d38e5fc9071ffcd20b4a763cc9ae4f252bb4e48fd66a835e252ada93ff480d6dd43dc62a641155a5", "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"},
+}
+
+func TestEncode(t *testing.T) {
+	for _, tt := range encodingTests {
+		t.Run(tt.s, func(t *testing.T) {
+			assert.Equal(t, tt.s, base58.Encode(mustDecodeHex(tt.hex)))
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	for _, tt := range encodingTests {
+		t.Run(tt.s, func(t *testing.T) {
+			b, err := base58.Decode(tt.s)
+			require.NoError(t, err)
+			assert.Equal(t, mustDecodeHex(tt.hex), b)
+		})
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	var tests = []string{"0", "O", "I", "l", "3mJr0", "O3yxU", "3sNI", "4kl8", "0OIl", "!@#$%^&*()-_=+~`", "abcd\xd80", "abcd\U000020BF"}
+	for _, s := range tests {
+		t.Run(s, func(t *testing.T) {
+			_, err := base58.Decode(s)
+			assert.ErrorIs(t, err, base58.ErrInvalidCharacter)
+		})
+	}
+}
+
+func TestCheckEncoding(t *testing.T) {
+	var tests = []struct {
+		in string
+		s  string
+	}{
+		{"\x14", "3MNQE1X"},
+		{"\x14 ", "B2Kr6dBE"},
+		{"\x14-", "B3jv1Aft"},
+		{"\x140", "B482yuaX"},
+		{"\x141", "B4CmeGAC"},
+		{"\x14-1", "mM7eUf6kB"},
+		{"\x1411", "mP7BMTDVH"},
+		{"\x14abc", "4QiVtDjUdeq"},
+		{"\x141234598760", "ZmNb8uQn5zvnUohNCEPP"},
+		{"\x14abcdefghijklmnopqrstuvwxyz", "K2RYDcKfupxwXdWhSAxQPCeiULntKm63UXyx5MvEH2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			assert.Equal(t, tt.s, base58.CheckEncode([]byte(tt.in)))
+
+			b, err := base58.CheckDecode(tt.s)
+			require.NoError(t, err)
+			assert.Equal(t, []byte(tt.in), b)
+		})
+	}
+}
+
+func TestCheckDecodeInvalid(t *testing.T) {
+	// checksum of the last test vector with the last character modified
+	_, err := base58.CheckDecode("K2RYDcKfupxwXdWhSAxQPCeiULntKm63UXyx5MvEH3")
+	assert.ErrorIs(t, err, base58.ErrInvalidChecksum)
+
+	// strings shorter than the checksum
+	for _, s := range []string{"", "1", "11", "111"} {
+		_, err := base58.CheckDecode(s)
+		assert.ErrorIs(t, err, base58.ErrInvalidLength)
+	}
+}
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/wollac/iota-crypto-demo/pkg/bech32/internal/base32"
+	"github.com/wollac/iota-crypto-demo/pkg/bech32/internal/charset"
 )
 
 const (
@@ -15,8 +16,6 @@ const (
 	separator       = '1'
 )
 
-var charset = newEncoding("qpzry9x8gf2tvdw0s3jn54khce6mua7l")
-
 // Encode encodes the hrp string and the src data as a Bech32 string.
 // It returns an error when the input is invalid.
 func Encode(hrp string, src []byte) (string, error) {
@@ -46,7 +45,7 @@ func Encode(hrp string, src []byte) (string, error) {
 	copy(data[dataLen:], bech32CreateChecksum(hrpLower, data[:dataLen]))
 
 	// enc the data part using the charset
-	chars := charset.encode(data)
+	chars := charset.Bech32.Encode(data)
 
 	// convert to a string using the corresponding charset
 	var res strings.Builder
@@ -93,7 +92,7 @@ func Decode(s string) (string, []byte, error) {
 	chars := s[hrpLen+1:]
 
 	// decode the data part
-	data, err := charset.decode(chars)
+	data, err := charset.Bech32.Decode(chars)
 	if err != nil {
 		return "", nil, &SyntaxError{fmt.Errorf("%w: non-charset character in data part", ErrInvalidCharacter), hrpLen + 1 + len(data)}
 	}
@@ -1,31 +1,22 @@
 package bech32
 
-var gen = []int{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+import (
+	"github.com/wollac/iota-crypto-demo/pkg/bech32/internal/bch"
+)
 
-// For more details on the checksum calculation, please refer to BIP 173.
-func bech32CreateChecksum(hrp string, blocks []byte) []byte {
-	values := append(bech32HrpExpand(hrp), blocks...)
-	polymod := bech32Polymod(append(values, []byte{0, 0, 0, 0, 0, 0}...)) ^ 1
-	res := make([]byte, 6)
-	for i := range res {
-		res[i] = byte((polymod >> (5 * (5 - i))) & 31)
-	}
-	return res
+// bech32Code is the BCH code of the 6-character checksum, for more details please refer to BIP 173.
+var bech32Code = &bch.Code{
+	Length: checksumLength,
+	Generator: [5]bch.Residue{
+		{Lo: 0x3b6a57b2}, {Lo: 0x26508e6d}, {Lo: 0x1ea119fa}, {Lo: 0x3d4233dd}, {Lo: 0x2a1462b3},
+	},
+	Init:   bch.Residue{Lo: 1},
+	Target: bch.Residue{Lo: 1},
 }
 
-// For more details on the polymod calculation, please refer to BIP 173.
-func bech32Polymod(values []byte) int {
-	chk := 1
-	for _, v := range values {
-		b := chk >> 25
-		chk = (chk&0x1ffffff)<<5 ^ int(v)
-		for i := range gen {
-			if (b>>i)&1 != 0 {
-				chk ^= gen[i]
-			}
-		}
-	}
-	return chk
+// For more details on the checksum calculation, please refer to BIP 173.
+func bech32CreateChecksum(hrp string, blocks []byte) []byte {
+	return bech32Code.Checksum(append(bech32HrpExpand(hrp), blocks...))
 }
 
 // For more details on String expansion, please refer to BIP 173.
@@ -43,5 +34,5 @@ func bech32HrpExpand(s string) []byte {
 
 // For more details on the checksum verification, please refer to BIP 173.
 func bech32VerifyChecksum(hrp string, data []byte) bool {
-	return bech32Polymod(append(bech32HrpExpand(hrp), data...)) == 1
+	return bech32Code.Verify(append(bech32HrpExpand(hrp), data...))
 }
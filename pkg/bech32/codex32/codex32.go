@@ -0,0 +1,240 @@
+/*
+Package codex32 implements codex32 strings as described in BIP-93.
+
+A codex32 string encodes a BIP-32 master seed, or a share of it, using the bech32 character set with the
+human-readable part "ms". In contrast to bech32, the strings are protected by a 13-character BCH checksum or, for
+seeds longer than 46 bytes, by a 15-character long checksum. A master seed can be split into shares using Shamir's
+Secret Sharing over GF(32), so that any threshold of them can be combined to recover the seed.
+*/
+package codex32
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wollac/iota-crypto-demo/pkg/bech32/internal/base32"
+	"github.com/wollac/iota-crypto-demo/pkg/bech32/internal/bch"
+	"github.com/wollac/iota-crypto-demo/pkg/bech32/internal/charset"
+)
+
+const (
+	// HRP is the human-readable part of all codex32 strings.
+	HRP = "ms"
+	// SecretIndex is the share index of the unshared master seed.
+	SecretIndex = 's'
+	// IdentifierLength is the number of characters of the identifier.
+	IdentifierLength = 4
+	// MinSeedSize is the minimum size, in bytes, of a master seed.
+	MinSeedSize = 16
+	// MaxSeedSize is the maximum size, in bytes, of a master seed.
+	MaxSeedSize = 64
+
+	separator = '1'
+	// the data part starts with the threshold, the identifier and the share index
+	headerLength = 1 + IdentifierLength + 1
+	// maximum length of the data part without checksum that uses the short checksum
+	maxShortDataLength = 80
+)
+
+var (
+	// ErrInvalidLength is returned when the length of a codex32 string or of its seed is invalid.
+	ErrInvalidLength = errors.New("invalid length")
+	// ErrInvalidPrefix is returned when a string does not start with the human-readable part and separator.
+	ErrInvalidPrefix = errors.New("missing prefix '" + HRP + string(separator) + "'")
+	// ErrMixedCase is returned when a string contains both upper and lower case characters.
+	ErrMixedCase = errors.New("mixed case")
+	// ErrInvalidCharacter is returned when a string contains a character not contained in the bech32 character set.
+	ErrInvalidCharacter = errors.New("invalid character")
+	// ErrInvalidChecksum is returned when the checksum of a string does not match.
+	ErrInvalidChecksum = errors.New("invalid checksum")
+	// ErrInvalidThreshold is returned when the threshold is not 0 or in [2, 9].
+	ErrInvalidThreshold = errors.New("invalid threshold")
+	// ErrInvalidIndex is returned when the share index is invalid.
+	ErrInvalidIndex = errors.New("invalid share index")
+	// ErrMismatchedShares is returned when shares with different parameters or duplicate indices are combined.
+	ErrMismatchedShares = errors.New("mismatched shares")
+	// ErrInsufficientShares is returned when fewer shares than the threshold are combined.
+	ErrInsufficientShares = errors.New("insufficient number of shares")
+)
+
+// shortCode is the BCH code of the 13-character checksum. The initial residue corresponds to the human-readable part.
+var shortCode = &bch.Code{
+	Length: 13,
+	Generator: [5]bch.Residue{
+		{Hi: 0x1, Lo: 0x9dc500ce73fde210},
+		{Hi: 0x1, Lo: 0xbfae00def77fe529},
+		{Hi: 0x1, Lo: 0xfbd920fffe7bee52},
+		{Hi: 0x1, Lo: 0x739640bdeee3fdad},
+		{Hi: 0x0, Lo: 0x7729a039cfc75f5a},
+	},
+	Init:   bch.Residue{Lo: 0x23181b3},
+	Target: bch.Residue{Hi: 0x1, Lo: 0x0ce0795c2fd1e62a},
+}
+
+// longCode is the BCH code of the 15-character checksum used for long codex32 strings.
+var longCode = &bch.Code{
+	Length: 15,
+	Generator: [5]bch.Residue{
+		{Hi: 0x3d5, Lo: 0x9d273535ea62d897},
+		{Hi: 0x7a9, Lo: 0xbecb6361c6c51507},
+		{Hi: 0x543, Lo: 0xf9b7e6c38d8a2a0e},
+		{Hi: 0x0c5, Lo: 0x77eaeccf1990d13c},
+		{Hi: 0x188, Lo: 0x7f74f8dc71b10651},
+	},
+	Init:   bch.Residue{Lo: 0x23181b3},
+	Target: bch.Residue{Hi: 0x433, Lo: 0x81e570bf4798ab26},
+}
+
+// Share represents a codex32 string, i.e. either an unshared master seed or one share of it.
+type Share struct {
+	threshold  int
+	identifier string
+	index      byte
+	payload    []uint8 // base32 digits of the seed including the padding bits
+}
+
+// New creates a new share of the given master seed. A threshold of 0 denotes an unshared master seed, whose index
+// must be SecretIndex.
+func New(threshold int, identifier string, index byte, seed []byte) (*Share, error) {
+	if threshold != 0 && (threshold < 2 || threshold > 9) {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidThreshold, threshold)
+	}
+	if len(seed) < MinSeedSize || len(seed) > MaxSeedSize {
+		return nil, fmt.Errorf("%w: seed must be between %d and %d bytes", ErrInvalidLength, MinSeedSize, MaxSeedSize)
+	}
+	header := fmt.Sprintf("%c%s%c", thresholdChar(threshold), strings.ToLower(identifier), lower(index))
+	if len(header) != headerLength {
+		return nil, fmt.Errorf("%w: identifier must contain %d characters", ErrInvalidLength, IdentifierLength)
+	}
+	if _, err := charset.Bech32.Decode(header); err != nil {
+		return nil, ErrInvalidCharacter
+	}
+	if err := validateHeader(header); err != nil {
+		return nil, err
+	}
+
+	payload := make([]uint8, base32.EncodedLen(len(seed)))
+	base32.Encode(payload, seed)
+	return &Share{threshold, header[1 : headerLength-1], header[headerLength-1], payload}, nil
+}
+
+// Parse parses the codex32 string s. The string must either be all lower or all upper case.
+func Parse(s string) (*Share, error) {
+	lowerS := strings.ToLower(s)
+	if lowerS != s && strings.ToUpper(s) != s {
+		return nil, ErrMixedCase
+	}
+	if !strings.HasPrefix(lowerS, HRP+string(separator)) {
+		return nil, ErrInvalidPrefix
+	}
+	chars := lowerS[len(HRP)+1:]
+
+	data, err := charset.Bech32.Decode(chars)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q at position %d", ErrInvalidCharacter, chars[len(data)], len(HRP)+1+len(data))
+	}
+	code := codeForDataPart(len(data))
+	if code == nil {
+		return nil, fmt.Errorf("%w: data part of %d characters", ErrInvalidLength, len(data))
+	}
+	if !code.Verify(data) {
+		return nil, ErrInvalidChecksum
+	}
+	if err := validateHeader(chars[:headerLength]); err != nil {
+		return nil, err
+	}
+
+	payload := data[headerLength : len(data)-code.Length]
+	seedLen := base32.DecodedLen(len(payload))
+	if 5*len(payload)-8*seedLen > 4 || seedLen < MinSeedSize || seedLen > MaxSeedSize {
+		return nil, fmt.Errorf("%w: payload of %d characters", ErrInvalidLength, len(payload))
+	}
+	return &Share{parseThreshold(chars[0]), chars[1 : headerLength-1], chars[headerLength-1], payload}, nil
+}
+
+// Threshold returns the number of shares required to recover the master seed or 0 for an unshared seed.
+func (s *Share) Threshold() int {
+	return s.threshold
+}
+
+// Identifier returns the 4-character identifier, which is the same for all shares of a master seed.
+func (s *Share) Identifier() string {
+	return s.identifier
+}
+
+// Index returns the share index. The share with index SecretIndex contains the master seed.
+func (s *Share) Index() byte {
+	return s.index
+}
+
+// Payload returns the data of the share. For the share with index SecretIndex, this is the master seed.
+// Any padding bits are ignored.
+func (s *Share) Payload() []byte {
+	digits := append([]uint8{}, s.payload...)
+	// clear the padding bits, as they can have any value
+	n := base32.DecodedLen(len(digits))
+	digits[len(digits)-1] &^= 1<<(5*len(digits)-8*n) - 1
+
+	dst := make([]byte, n)
+	if _, err := base32.Decode(dst, digits); err != nil {
+		panic(err)
+	}
+	return dst
+}
+
+// String returns the lower case codex32 string of the share.
+func (s *Share) String() string {
+	data := make([]uint8, 0, headerLength+len(s.payload))
+	header, _ := charset.Bech32.Decode(fmt.Sprintf("%c%s%c", thresholdChar(s.threshold), s.identifier, s.index))
+	data = append(data, header...)
+	data = append(data, s.payload...)
+
+	code := shortCode
+	if len(data) > maxShortDataLength {
+		code = longCode
+	}
+	return HRP + string(separator) + charset.Bech32.Encode(append(data, code.Checksum(data)...))
+}
+
+// codeForDataPart returns the BCH code used for a data part of length n including the checksum, or nil if no
+// valid codex32 string has this length.
+func codeForDataPart(n int) *bch.Code {
+	minShort := headerLength + base32.EncodedLen(MinSeedSize) + shortCode.Length
+	maxLong := headerLength + base32.EncodedLen(MaxSeedSize) + longCode.Length
+	switch {
+	case n >= minShort && n <= maxShortDataLength+shortCode.Length:
+		return shortCode
+	case n > maxShortDataLength+longCode.Length && n <= maxLong:
+		return longCode
+	default:
+		return nil
+	}
+}
+
+// validateHeader checks the threshold and share index of the lower case header.
+func validateHeader(header string) error {
+	k := header[0]
+	if k != '0' && (k < '2' || k > '9') {
+		return fmt.Errorf("%w: %q", ErrInvalidThreshold, k)
+	}
+	if k == '0' && header[headerLength-1] != SecretIndex {
+		return fmt.Errorf("%w: unshared secret must use index %q", ErrInvalidIndex, SecretIndex)
+	}
+	return nil
+}
+
+func thresholdChar(threshold int) byte {
+	return '0' + byte(threshold)
+}
+
+func parseThreshold(c byte) int {
+	return int(c - '0')
+}
+
+func lower(c byte) byte {
+	if c >= 'A' && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
@@ -0,0 +1,157 @@
+package codex32_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wollac/iota-crypto-demo/internal/hexutil"
+	"github.com/wollac/iota-crypto-demo/pkg/bech32/codex32"
+)
+
+type Test struct {
+	Description string            `json:"description"`
+	Shares      []string          `json:"shares"`
+	Seed        hexutil.Bytes     `json:"seed"`
+	Derived     map[string]string `json:"derived"`
+}
+
+func TestCodex32(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("testdata", t.Name()+".json"))
+	require.NoError(t, err)
+	var tests []Test
+	require.NoError(t, json.Unmarshal(b, &tests))
+
+	for _, tt := range tests {
+		t.Run(tt.Description, func(t *testing.T) {
+			shares := make([]*codex32.Share, len(tt.Shares))
+			for i, s := range tt.Shares {
+				shares[i], err = codex32.Parse(s)
+				require.NoError(t, err)
+				assert.Equal(t, strings.ToLower(s), shares[i].String())
+			}
+
+			seed, err := codex32.Combine(shares)
+			require.NoError(t, err)
+			assert.EqualValues(t, tt.Seed, seed)
+
+			for index, expected := range tt.Derived {
+				share, err := codex32.Interpolate(shares, index[0])
+				require.NoError(t, err)
+				assert.Equal(t, strings.ToLower(expected), share.String())
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	seed := hexutil.MustDecodeString("318c6318c6318c6318c6318c6318c631")
+	share, err := codex32.New(0, "TEST", 'S', seed)
+	require.NoError(t, err)
+	assert.Equal(t, 0, share.Threshold())
+	assert.Equal(t, "test", share.Identifier())
+	assert.EqualValues(t, codex32.SecretIndex, share.Index())
+	assert.Equal(t, seed, share.Payload())
+	// the padding bits of the test vector differ, but are ignored
+	assert.Equal(t, "ms10testsxxxxxxxxxxxxxxxxxxxxxxxxxywvfucx7rv8mk8", share.String())
+}
+
+func TestSplit(t *testing.T) {
+	for _, size := range []int{codex32.MinSeedSize, 32, codex32.MaxSeedSize} {
+		seed := make([]byte, size)
+		for i := range seed {
+			seed[i] = byte(i)
+		}
+		shares, err := codex32.Split(seed, 3, 5, "cash")
+		require.NoError(t, err)
+		require.Len(t, shares, 5)
+
+		for _, share := range shares {
+			s, err := codex32.Parse(share.String())
+			require.NoError(t, err)
+			assert.Equal(t, share, s)
+		}
+
+		for _, subset := range [][]*codex32.Share{shares[:3], shares[2:], {shares[4], shares[0], shares[3]}} {
+			secret, err := codex32.Combine(subset)
+			require.NoError(t, err)
+			assert.Equal(t, seed, secret)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	// valid checksum, but the data part of 94 characters is too long for the short and too short for the long checksum
+	long := "ms10tests" + strings.Repeat("x", 75) + "qelpaxwk0jz4e"
+	var tests = []*struct {
+		name string
+		s    string
+		err  error
+	}{
+		{"invalid checksum", "ms10testsxxxxxxxxxxxxxxxxxxxxxxxxxx4nzvca9cmczlq", codex32.ErrInvalidChecksum},
+		{"mixed case", "ms10testsxxxxxxxxxxxxxxxxxxxxxxxxxx4nzvca9cmczLW", codex32.ErrMixedCase},
+		{"invalid prefix", "mx10testsxxxxxxxxxxxxxxxxxxxxxxxxxx4nzvca9cmczlw", codex32.ErrInvalidPrefix},
+		{"invalid character", "ms10testsxxxxxxxxxxxxxxxxxxxbxxxxxx4nzvca9cmczlw", codex32.ErrInvalidCharacter},
+		{"threshold 1", "ms11testsxxxxxxxxxxxxxxxxxxxxxxxxxxq0cjgfeyp0r07", codex32.ErrInvalidCharacter},
+		{"invalid threshold", "ms1atestsxxxxxxxxxxxxxxxxxxxxxxxxxx0jvyjz6w79yex", codex32.ErrInvalidThreshold},
+		{"unshared index", "ms10testaxxxxxxxxxxxxxxxxxxxxxxxxxxzd568kp774rau", codex32.ErrInvalidIndex},
+		{"invalid padding", "ms12testaxxxxxxxxxxxxxxxxxxxxxxxxx0kejx2tqykufn", codex32.ErrInvalidLength},
+		{"short seed", "ms10testsqqqqqqqqqqqqqqqqqqqqqqqqt378khhf2h3jt", codex32.ErrInvalidLength},
+		{"length 94", long, codex32.ErrInvalidLength},
+		{"length 95", long + "q", codex32.ErrInvalidLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := codex32.Parse(tt.s)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestCombineInvalid(t *testing.T) {
+	seed := make([]byte, codex32.MinSeedSize)
+	a, err := codex32.Split(seed, 2, 3, "test")
+	require.NoError(t, err)
+	b, err := codex32.Split(seed, 2, 3, "tset")
+	require.NoError(t, err)
+	c, err := codex32.Split(seed, 3, 3, "test")
+	require.NoError(t, err)
+
+	var tests = []*struct {
+		name   string
+		shares []*codex32.Share
+		err    error
+	}{
+		{"no shares", nil, codex32.ErrInsufficientShares},
+		{"insufficient shares", a[:1], codex32.ErrInsufficientShares},
+		{"different identifiers", []*codex32.Share{a[0], b[1]}, codex32.ErrMismatchedShares},
+		{"different thresholds", []*codex32.Share{a[0], a[1], c[2]}, codex32.ErrMismatchedShares},
+		{"duplicate index", []*codex32.Share{a[0], a[0]}, codex32.ErrMismatchedShares},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := codex32.Combine(tt.shares)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestSplitInvalid(t *testing.T) {
+	seed := make([]byte, codex32.MinSeedSize)
+	_, err := codex32.Split(seed, 1, 3, "test")
+	assert.ErrorIs(t, err, codex32.ErrInvalidThreshold)
+	_, err = codex32.Split(seed, 3, 2, "test")
+	assert.ErrorIs(t, err, codex32.ErrInvalidThreshold)
+	_, err = codex32.Split(seed, 2, 3, "tes")
+	assert.ErrorIs(t, err, codex32.ErrInvalidLength)
+	_, err = codex32.Split(seed, 2, 3, "test!")
+	assert.ErrorIs(t, err, codex32.ErrInvalidLength)
+	_, err = codex32.Split(seed, 2, 3, "tesb")
+	assert.ErrorIs(t, err, codex32.ErrInvalidCharacter)
+	_, err = codex32.Split(seed[:15], 2, 3, "test")
+	assert.ErrorIs(t, err, codex32.ErrInvalidLength)
+}
@@ -0,0 +1,148 @@
+package codex32
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/wollac/iota-crypto-demo/internal/wipe"
+	"github.com/wollac/iota-crypto-demo/pkg/bech32/internal/charset"
+)
+
+// shareIndices contains the indices used for the shares created by Split in that order.
+const shareIndices = "acdefghjklmnpqrtuvwxyz023456789"
+
+// gfMul returns the product of a and b in GF(32) with the modulus x⁵ + x³ + 1 as used in bech32.
+func gfMul(a, b uint8) uint8 {
+	var p uint8
+	for i := 0; i < 5; i++ {
+		p ^= -(b & 1) & a
+		// multiply a by x and reduce, if the degree would exceed 4
+		a <<= 1
+		a ^= -(a >> 5) & 0x29
+		b >>= 1
+	}
+	return p
+}
+
+// gfInv returns the multiplicative inverse of a in GF(32), i.e. a³⁰. The inverse of 0 is 0.
+func gfInv(a uint8) uint8 {
+	// a³⁰ = a² ⋅ a⁴ ⋅ a⁸ ⋅ a¹⁶
+	var r uint8 = 1
+	for i := 0; i < 4; i++ {
+		a = gfMul(a, a)
+		r = gfMul(r, a)
+	}
+	return r
+}
+
+// digit returns the GF(32) element corresponding to the character c.
+func digit(c byte) uint8 {
+	d, err := charset.Bech32.Decode(string(c))
+	if err != nil {
+		panic(err)
+	}
+	return d[0]
+}
+
+// Split splits the master seed into count shares with the given identifier, of which threshold shares are required
+// to recover the seed. The first threshold-1 shares are generated randomly, the remaining shares are derived from
+// them and the seed, so that any threshold of shares determine the polynomials over GF(32).
+func Split(seed []byte, threshold, count int, identifier string) ([]*Share, error) {
+	if threshold < 2 || threshold > 9 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidThreshold, threshold)
+	}
+	if count < threshold || count > len(shareIndices) {
+		return nil, fmt.Errorf("%w: %d shares with threshold %d", ErrInvalidThreshold, count, threshold)
+	}
+	secret, err := New(threshold, identifier, SecretIndex, seed)
+	if err != nil {
+		return nil, err
+	}
+
+	shares := make([]*Share, 0, count)
+	random := make([]byte, len(seed))
+	defer wipe.Bytes(random)
+	for i := 0; i < threshold-1; i++ {
+		if _, err := rand.Read(random); err != nil {
+			return nil, err
+		}
+		share, err := New(threshold, identifier, shareIndices[i], random)
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, share)
+	}
+
+	base := append([]*Share{secret}, shares...)
+	for i := threshold - 1; i < count; i++ {
+		share, err := Interpolate(base, shareIndices[i])
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, share)
+	}
+	return shares, nil
+}
+
+// Interpolate derives the share with the given index from at least threshold shares of the same master seed.
+// All shares must have the same threshold, identifier and length, and distinct indices.
+func Interpolate(shares []*Share, index byte) (*Share, error) {
+	if len(shares) == 0 {
+		return nil, ErrInsufficientShares
+	}
+	index = lower(index)
+	if _, err := charset.Bech32.Decode(string(index)); err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidIndex, index)
+	}
+
+	first := shares[0]
+	if first.threshold == 0 {
+		return nil, fmt.Errorf("%w: unshared secret cannot be interpolated", ErrInvalidThreshold)
+	}
+	xs := make([]uint8, len(shares))
+	for i, s := range shares {
+		if s.threshold != first.threshold || s.identifier != first.identifier || len(s.payload) != len(first.payload) {
+			return nil, ErrMismatchedShares
+		}
+		for _, o := range shares[:i] {
+			if o.index == s.index {
+				return nil, fmt.Errorf("%w: duplicate index %q", ErrMismatchedShares, s.index)
+			}
+		}
+		xs[i] = digit(s.index)
+	}
+	if len(shares) < first.threshold {
+		return nil, fmt.Errorf("%w: %d of %d", ErrInsufficientShares, len(shares), first.threshold)
+	}
+
+	result := &Share{first.threshold, first.identifier, index, make([]uint8, len(first.payload))}
+	x := digit(index)
+	for i, s := range shares {
+		// the Lagrange basis polynomial lᵢ(x) = ∏ (x - xⱼ) / (xᵢ - xⱼ), where subtraction is the XOR in GF(32)
+		var num, den uint8 = 1, 1
+		for j := range shares {
+			if i != j {
+				num = gfMul(num, x^xs[j])
+				den = gfMul(den, xs[i]^xs[j])
+			}
+		}
+		basis := gfMul(num, gfInv(den))
+		for k := range result.payload {
+			result.payload[k] ^= gfMul(basis, s.payload[k])
+		}
+	}
+	return result, nil
+}
+
+// Combine recovers the master seed from the given shares. This is either a single share with threshold 0 or at
+// least threshold shares of the same master seed.
+func Combine(shares []*Share) ([]byte, error) {
+	if len(shares) == 1 && shares[0].threshold == 0 {
+		return shares[0].Payload(), nil
+	}
+	secret, err := Interpolate(shares, SecretIndex)
+	if err != nil {
+		return nil, err
+	}
+	return secret.Payload(), nil
+}
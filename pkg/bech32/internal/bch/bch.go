@@ -0,0 +1,81 @@
+// Package bch implements the BCH codes over GF(32) used as checksums of bech32-like strings.
+package bch
+
+// Residue is the state of the polymod calculation. It supports codes with up to 25 checksum characters.
+type Residue struct {
+	Hi, Lo uint64
+}
+
+func (r Residue) xor(o Residue) Residue {
+	return Residue{r.Hi ^ o.Hi, r.Lo ^ o.Lo}
+}
+
+// rsh returns the 64 least significant bits of the residue shifted right by n bits.
+func (r Residue) rsh(n uint) uint64 {
+	if n >= 64 {
+		return r.Hi >> (n - 64)
+	}
+	return r.Hi<<(64-n) | r.Lo>>n
+}
+
+// shiftIn returns the residue shifted left by 5 bits with v added, truncated to the given number of bits, together
+// with the 5 bits that have been shifted out.
+func (r Residue) shiftIn(v uint8, bits uint) (Residue, uint8) {
+	top := r.rsh(bits - 5)
+	r = Residue{r.Hi<<5 | r.Lo>>59, r.Lo<<5 | uint64(v)}
+	// clear all bits above the residue size
+	if bits >= 64 {
+		r.Hi &= 1<<(bits-64) - 1
+	} else {
+		r.Hi = 0
+		r.Lo &= 1<<bits - 1
+	}
+	return r, uint8(top & 31)
+}
+
+// Code is a BCH code over GF(32) with a checksum of Length characters.
+// A checksum is valid, if the polymod of the data including the checksum equals Target.
+type Code struct {
+	// Length is the number of checksum characters.
+	Length int
+	// Generator contains the generator polynomial multiplied by 1, 2, 4, 8 and 16 in GF(32).
+	Generator [5]Residue
+	// Init is the initial residue.
+	Init Residue
+	// Target is the residue of valid checksums.
+	Target Residue
+}
+
+// Polymod computes the residue of the values, each value must be in [0, 31].
+func (c *Code) Polymod(values []uint8) Residue {
+	bits := uint(5 * c.Length)
+	r := c.Init
+	for _, v := range values {
+		var b uint8
+		r, b = r.shiftIn(v, bits)
+		for i := range c.Generator {
+			if (b>>i)&1 != 0 {
+				r = r.xor(c.Generator[i])
+			}
+		}
+	}
+	return r
+}
+
+// Checksum returns the Length checksum characters for the values.
+func (c *Code) Checksum(values []uint8) []uint8 {
+	data := make([]uint8, len(values)+c.Length)
+	copy(data, values)
+	polymod := c.Polymod(data).xor(c.Target)
+
+	res := make([]uint8, c.Length)
+	for i := range res {
+		res[i] = uint8(polymod.rsh(uint(5*(c.Length-1-i))) & 31)
+	}
+	return res
+}
+
+// Verify reports whether values ends with a valid checksum.
+func (c *Code) Verify(values []uint8) bool {
+	return c.Polymod(values) == c.Target
+}
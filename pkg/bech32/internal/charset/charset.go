@@ -0,0 +1,62 @@
+// Package charset implements the conversion between base32 digits and the characters of bech32-like strings.
+package charset
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidCharacter reports an attempt to decode a character that is not part of the alphabet.
+var ErrInvalidCharacter = errors.New("invalid character")
+
+// Bech32 is the encoding using the alphabet of BIP-173.
+var Bech32 = NewEncoding("qpzry9x8gf2tvdw0s3jn54khce6mua7l")
+
+// Encoding is a radix 32 encoding defined by a 32-character alphabet.
+type Encoding struct {
+	enc    [32]byte
+	decMap [256]uint8
+}
+
+// NewEncoding returns a new encoding defined by the given alphabet,
+// which must be a 32-byte string.
+func NewEncoding(charset string) *Encoding {
+	if len(charset) != 32 {
+		panic("encoding alphabet is not 32-bytes long")
+	}
+
+	e := new(Encoding)
+	copy(e.enc[:], charset)
+
+	for i := 0; i < len(e.decMap); i++ {
+		e.decMap[i] = 0xFF
+	}
+	for i := 0; i < len(charset); i++ {
+		e.decMap[charset[i]] = uint8(i)
+	}
+	return e
+}
+
+// Encode converts the base32 digits of src into a string.
+func (e *Encoding) Encode(src []uint8) string {
+	var dst strings.Builder
+	dst.Grow(len(src))
+	for i := range src {
+		dst.WriteByte(e.enc[src[i]])
+	}
+	return dst.String()
+}
+
+// Decode converts the string into base32 digits.
+// On error, the digits decoded before the invalid character are returned.
+func (e *Encoding) Decode(src string) ([]uint8, error) {
+	dst := make([]uint8, len(src))
+	for i := range src {
+		d := e.decMap[src[i]]
+		if d == 0xFF {
+			return dst[:i], ErrInvalidCharacter
+		}
+		dst[i] = e.decMap[src[i]]
+	}
+	return dst, nil
+}
@@ -31,7 +31,7 @@ func TestBIP39(t *testing.T) {
 	for _, tv := range tvs {
 		t.Run(tv.Language, func(t *testing.T) {
 			require.NoError(t, SetWordList(strings.ToLower(tv.Language)))
-			runTests(t, tv.Tests)
+			runTests(t, defaultCodec, tv.Tests)
 		})
 	}
 }
@@ -119,8 +119,23 @@ func TestMnemonicToSeed(t *testing.T) {
 	}
 }
 
+func TestSeedWipe(t *testing.T) {
+	require.NoError(t, SetWordList(defaultLanguage))
+
+	seed, err := MnemonicToSeed(ParseMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"), "")
+	require.NoError(t, err)
+	require.NotEqual(t, make([]byte, SeedSize), []byte(seed))
+
+	seed.Wipe()
+	assert.Equal(t, make([]byte, SeedSize), []byte(seed))
+}
+
 func readJSONTests(t *testing.T) []TestVector {
-	b, err := os.ReadFile(filepath.Join("testdata", t.Name()+".json"))
+	return readTestVectors(t, t.Name())
+}
+
+func readTestVectors(t *testing.T, name string) []TestVector {
+	b, err := os.ReadFile(filepath.Join("testdata", name+".json"))
 	require.NoError(t, err)
 
 	var tvs []TestVector
@@ -129,18 +144,18 @@ func readJSONTests(t *testing.T) []TestVector {
 	return tvs
 }
 
-func runTests(t *testing.T, tests []Test) {
+func runTests(t *testing.T, c *Codec, tests []Test) {
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
-			ms, err := EntropyToMnemonic(tt.Entropy)
+			ms, err := c.EntropyToMnemonic(tt.Entropy)
 			assert.NoError(t, err)
 			assert.Equal(t, tt.Mnemonic, ms)
 
-			ent, err := MnemonicToEntropy(tt.Mnemonic)
+			ent, err := c.MnemonicToEntropy(tt.Mnemonic)
 			assert.NoError(t, err)
 			assert.EqualValues(t, tt.Entropy, ent)
 
-			seed, err := MnemonicToSeed(tt.Mnemonic, tt.Passphrase)
+			seed, err := c.MnemonicToSeed(tt.Mnemonic, tt.Passphrase)
 			assert.NoError(t, err)
 			assert.EqualValues(t, tt.Seed, seed)
 		})
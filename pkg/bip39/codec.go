@@ -0,0 +1,128 @@
+package bip39
+
+import (
+	"crypto/sha512"
+	"math/big"
+
+	"github.com/wollac/iota-crypto-demo/internal/wipe"
+	"github.com/wollac/iota-crypto-demo/pkg/bip39/wordlist"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Codec converts between entropy, mnemonics and seeds using a fixed word list.
+// In contrast to the package-level functions, a Codec is not affected by SetWordList and is safe for concurrent use.
+type Codec struct {
+	list wordlist.List
+}
+
+// NewCodec creates a new Codec using the given word list.
+func NewCodec(list wordlist.List) *Codec {
+	return &Codec{list: list}
+}
+
+// NewCodecForLanguage creates a new Codec using the word list registered for the given language.
+func NewCodecForLanguage(language string) (*Codec, error) {
+	list, err := lookupWordList(language)
+	if err != nil {
+		return nil, err
+	}
+	return NewCodec(list), nil
+}
+
+// WordList returns the word list used by c.
+func (c *Codec) WordList() wordlist.List {
+	return c.list
+}
+
+// MnemonicToSeed validates the mnemonic against the word list of c and derives the seed using the given passphrase.
+func (c *Codec) MnemonicToSeed(mnemonic Mnemonic, passphrase string) (Seed, error) {
+	// validate mnemonic
+	entropy, err := c.MnemonicToEntropy(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	wipe.Bytes(entropy)
+
+	// UTF-8 NFKD
+	passphrase = norm.NFKD.String(passphrase)
+	password := []byte(mnemonic.String())
+	key := pbkdf2.Key(password, []byte("mnemonic"+passphrase), 2048, SeedSize, sha512.New)
+	wipe.Bytes(password)
+	return key, nil
+}
+
+// EntropyToMnemonic generates a BIP-39 mnemonic sentence from the word list of c that satisfies the given entropy
+// length.
+func (c *Codec) EntropyToMnemonic(entropy []byte) (Mnemonic, error) {
+	if err := validateEntropy(entropy); err != nil {
+		return nil, err
+	}
+
+	// compute entropy bit count, denoted by ENT
+	bitsEntropy := len(entropy) * 8
+
+	// the checksum is generated by taking the first ENT / 32 bits of the entropy's SHA256 hash
+	bitsChecksum := bitsEntropy / 32
+	checksum := computeChecksum(entropy, bitsChecksum)
+
+	// the checksum is appended to the end of the initial entropy
+	bigEntropy := new(big.Int).SetBytes(entropy)
+	bigEntropy.Lsh(bigEntropy, uint(bitsChecksum))
+	bigEntropy.Or(bigEntropy, checksum)
+
+	// allocate the number of mnemonic words soon to be generated
+	words := make(Mnemonic, entropyBitsToWordCount(bitsEntropy))
+
+	// split into groups of 11 bits, each encoding a number from 0-2047, serving as an index into a word list
+	wordIndex := big.NewInt(0)
+	for i := len(words) - 1; i >= 0; i-- {
+		// get least significant 11 bits
+		wordIndex.And(bigEntropy, wordIndexMask)
+		// convert the 11 bits to index
+		words[i] = c.list.Word(int(wordIndex.Int64()))
+
+		// shift out least significant 11 bits
+		bigEntropy.Rsh(bigEntropy, wordlist.IndexBits)
+	}
+
+	return words, nil
+}
+
+// MnemonicToEntropy takes a BIP-39 mnemonic sentence using the word list of c and returns the initial
+// entropy used. If the sentence is invalid, an error is returned.
+func (c *Codec) MnemonicToEntropy(mnemonic Mnemonic) ([]byte, error) {
+	if err := validateMnemonic(c.list, mnemonic); err != nil {
+		return nil, err
+	}
+
+	// compute bit counts
+	bitsEntropy := wordCountToEntropyBits(len(mnemonic))
+	bitsChecksum := bitsEntropy / entropyMultiple
+
+	// use a big.Int to decode words for easier bitwise operations
+	decoder := big.NewInt(0)
+	for _, word := range mnemonic {
+		wordIndex := c.list.Index(word)
+		if wordIndex < 0 || wordIndex >= wordlist.Count {
+			panic("invalid word index")
+		}
+
+		decoder.Lsh(decoder, wordlist.IndexBits)
+		decoder.Or(decoder, big.NewInt(int64(wordIndex)))
+	}
+
+	// the checksum corresponds to the last few bits of the decoded bytes
+	checksumMask := new(big.Int).Lsh(bigOne, uint(bitsChecksum))
+	checksumMask.Sub(checksumMask, bigOne)
+	checksum := new(big.Int).And(decoder, checksumMask)
+
+	entropy := decoder.Rsh(decoder, uint(bitsChecksum)).Bytes()
+	entropy = padBytes(entropy, bitsEntropy/8)
+
+	// check whether the decoded checksum matches the computed
+	if checksum.Cmp(computeChecksum(entropy, bitsChecksum)) != 0 {
+		return nil, ErrInvalidChecksum
+	}
+	return entropy, nil
+}
@@ -0,0 +1,58 @@
+package bip39
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodec(t *testing.T) {
+	tvs := readTestVectors(t, "TestBIP39")
+	for _, tv := range tvs {
+		t.Run(tv.Language, func(t *testing.T) {
+			c, err := NewCodecForLanguage(strings.ToLower(tv.Language))
+			require.NoError(t, err)
+			runTests(t, c, tv.Tests)
+		})
+	}
+}
+
+func TestCodecConcurrent(t *testing.T) {
+	tvs := readTestVectors(t, "TestBIP39")
+
+	var wg sync.WaitGroup
+	for _, tv := range tvs {
+		c, err := NewCodecForLanguage(strings.ToLower(tv.Language))
+		require.NoError(t, err)
+
+		// use each codec from several goroutines at the same time
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func(c *Codec, tests []Test) {
+				defer wg.Done()
+				for _, tt := range tests {
+					ms, err := c.EntropyToMnemonic(tt.Entropy)
+					assert.NoError(t, err)
+					assert.Equal(t, tt.Mnemonic, ms)
+
+					ent, err := c.MnemonicToEntropy(tt.Mnemonic)
+					assert.NoError(t, err)
+					assert.EqualValues(t, tt.Entropy, ent)
+				}
+			}(c, tv.Tests)
+		}
+	}
+	wg.Wait()
+}
+
+func TestNewCodecForLanguage(t *testing.T) {
+	_, err := NewCodecForLanguage("klingon")
+	assert.Error(t, err)
+
+	c, err := NewCodecForLanguage(defaultLanguage)
+	require.NoError(t, err)
+	assert.Equal(t, "abandon", c.WordList().Word(0))
+}
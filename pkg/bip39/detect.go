@@ -0,0 +1,74 @@
+package bip39
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/wollac/iota-crypto-demo/internal/wipe"
+)
+
+var (
+	// ErrUnknownLanguage is returned when no word of a mnemonic is contained in any registered word list.
+	ErrUnknownLanguage = errors.New("unknown mnemonic language")
+	// ErrAmbiguousLanguage is returned when a mnemonic is valid in more than one registered word list.
+	ErrAmbiguousLanguage = errors.New("ambiguous mnemonic language")
+)
+
+// DetectLanguage detects the language of the mnemonic by scoring it against all registered word lists.
+// The score of a word list is the number of words of the mnemonic it contains and only the languages with the best
+// score are considered. As some words are contained in several word lists, e.g. in English and French, languages in
+// which the checksum of the mnemonic is invalid are discarded.
+//
+// If the mnemonic is valid in exactly one language, only this language is returned. If it is valid in several
+// languages, all of them are returned together with ErrAmbiguousLanguage. Otherwise, the most likely languages are
+// returned together with the error that prevents the mnemonic from being valid in these languages.
+func DetectLanguage(mnemonic Mnemonic) ([]string, error) {
+	var (
+		candidates []*Codec
+		names      []string
+		bestScore  = 1 // a word list must contain at least one word
+	)
+	for _, lc := range codecs() {
+		c := lc.codec
+		score := 0
+		for _, word := range mnemonic {
+			if c.list.Contains(word) {
+				score++
+			}
+		}
+		if score > bestScore {
+			candidates, names, bestScore = nil, nil, score
+		}
+		if score == bestScore {
+			candidates = append(candidates, c)
+			names = append(names, lc.language)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, ErrUnknownLanguage
+	}
+	if bestScore < len(mnemonic) {
+		return names, fmt.Errorf("%w: only %d of %d words found", ErrInvalidMnemonic, bestScore, len(mnemonic))
+	}
+
+	// use the checksum to resolve words contained in more than one word list
+	var valid []string
+	var lastErr error
+	for i, c := range candidates {
+		entropy, err := c.MnemonicToEntropy(mnemonic)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		wipe.Bytes(entropy)
+		valid = append(valid, names[i])
+	}
+	switch len(valid) {
+	case 0:
+		return names, lastErr
+	case 1:
+		return valid, nil
+	default:
+		return valid, ErrAmbiguousLanguage
+	}
+}
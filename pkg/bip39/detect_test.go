@@ -0,0 +1,101 @@
+package bip39
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wollac/iota-crypto-demo/pkg/bip39/internal/wordlists"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	// encode the entropies of the test vectors with each registered word list
+	tests := readTestVectors(t, "TestBIP39")[0].Tests
+	for _, lc := range codecs() {
+		t.Run(lc.language, func(t *testing.T) {
+			for _, tt := range tests {
+				mnemonic, err := lc.codec.EntropyToMnemonic(tt.Entropy)
+				require.NoError(t, err)
+
+				languages, err := DetectLanguage(mnemonic)
+				assert.Contains(t, languages, lc.language)
+				// many characters are contained in both Chinese word lists, so that the checksum cannot always decide
+				if len(languages) > 1 && strings.HasPrefix(lc.language, "chinese") {
+					assert.ErrorIs(t, err, ErrAmbiguousLanguage)
+				} else {
+					assert.NoError(t, err)
+				}
+			}
+		})
+	}
+}
+
+func TestDetectLanguageRegisterConcurrent(t *testing.T) {
+	mnemonic := ParseMnemonic("crucial relief volume brave figure correct panda noble orange stable loyal excuse")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			// re-registering a word list invalidates the cached codecs
+			RegisterWordList("english", wordlists.English)
+		}()
+		go func() {
+			defer wg.Done()
+			languages, err := DetectLanguage(mnemonic)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"english"}, languages)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestDetectLanguageShared(t *testing.T) {
+	var tests = []*struct {
+		name      string
+		mnemonic  Mnemonic
+		languages []string
+		err       error
+	}{
+		{
+			"checksum resolves",
+			ParseMnemonic("crucial relief volume brave figure correct panda noble orange stable loyal excuse"),
+			[]string{"english"},
+			nil,
+		},
+		{
+			"ambiguous",
+			ParseMnemonic("essence civil palace animal machine nation sentence volume voyage abandon tunnel capable"),
+			[]string{"english", "french"},
+			ErrAmbiguousLanguage,
+		},
+		{
+			"invalid checksum",
+			ParseMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon"),
+			[]string{"english", "french"},
+			ErrInvalidChecksum,
+		},
+		{
+			"unknown word",
+			ParseMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon brummagem"),
+			[]string{"english", "french"},
+			ErrInvalidMnemonic,
+		},
+		{
+			"unknown language",
+			ParseMnemonic(strings.Repeat("brummagem ", 12)),
+			nil,
+			ErrUnknownLanguage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			languages, err := DetectLanguage(tt.mnemonic)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.languages, languages)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Package electrum implements the "new-style" mnemonic seeds used by the Electrum wallet.
+
+Electrum seeds are written using the BIP-39 word lists, but they do not contain a BIP-39 checksum. Instead, the
+version of the seed is encoded in the prefix of HMAC-SHA512("Seed version", mnemonic) and the seed is derived
+using PBKDF2 with "electrum" instead of "mnemonic" as salt prefix. Before hashing, the mnemonic and the
+passphrase are normalized: Besides NFKD normalization, they are converted to lower case and accents are removed.
+
+This package supports the standard and segwit seed versions. Two-factor authentication seeds and the old
+Electrum seeds based on a different word list are not supported.
+
+The specification can be found in https://electrum.readthedocs.io/en/latest/seedphrase.html.
+*/
+package electrum
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/wollac/iota-crypto-demo/internal/wipe"
+	"github.com/wollac/iota-crypto-demo/pkg/bip39"
+	"github.com/wollac/iota-crypto-demo/pkg/bip39/internal/wordlists"
+	"github.com/wollac/iota-crypto-demo/pkg/bip39/wordlist"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+var (
+	// ErrInvalidMnemonic is returned when a mnemonic does not encode any supported seed version.
+	ErrInvalidMnemonic = errors.New("invalid Electrum mnemonic")
+	// ErrInvalidVersion is returned when trying to use an unknown seed version.
+	ErrInvalidVersion = errors.New("invalid seed version")
+	// ErrUnsupportedLanguage is returned when a mnemonic of a language not supported by Electrum is requested.
+	ErrUnsupportedLanguage = errors.New("unsupported language")
+)
+
+// Version is the version of an Electrum seed, determining the type of wallet that is derived from it.
+type Version int
+
+// Seed versions supported by this package.
+const (
+	// Standard denotes seeds of P2PKH wallets using BIP-32 derivation.
+	Standard Version = iota + 1
+	// Segwit denotes seeds of native SegWit (P2WPKH) wallets using BIP-32 derivation.
+	Segwit
+)
+
+// hex encoded prefixes of HMAC-SHA512("Seed version", mnemonic) for each version
+var prefixes = map[Version]string{
+	Standard: "01",
+	Segwit:   "100",
+}
+
+// String returns the name of the seed version as used by Electrum.
+func (v Version) String() string {
+	switch v {
+	case Standard:
+		return "standard"
+	case Segwit:
+		return "segwit"
+	default:
+		return fmt.Sprintf("Version(%d)", int(v))
+	}
+}
+
+// Electrum generates new mnemonics only for the following languages.
+// Its Portuguese word list differs from the one of BIP-0039, so Portuguese is not supported.
+var languages = map[string]func() wordlist.List{
+	"english":            wordlists.English,
+	"spanish":            wordlists.Spanish,
+	"japanese":           wordlists.Japanese,
+	"chinese_simplified": wordlists.ChineseSimplified,
+}
+
+const (
+	// SeedSize is the size, in bytes, of an Electrum seed.
+	SeedSize = 64
+
+	// number of entropy bits of a new mnemonic
+	entropyBits = 132
+	// number of PBKDF2 iterations of the seed derivation
+	iterations = 2048
+)
+
+// DetectVersion returns the seed version encoded in the mnemonic.
+// It returns ErrInvalidMnemonic, if the mnemonic does not correspond to any supported version.
+func DetectVersion(mnemonic bip39.Mnemonic) (Version, error) {
+	s := versionHash(mnemonic)
+	for _, v := range []Version{Standard, Segwit} {
+		if strings.HasPrefix(s, prefixes[v]) {
+			return v, nil
+		}
+	}
+	return 0, ErrInvalidMnemonic
+}
+
+// MnemonicToSeed validates the mnemonic and derives the seed using the given passphrase.
+// Unlike in BIP-39, the passphrase is normalized the same way as the mnemonic, i.e. it is case-insensitive.
+func MnemonicToSeed(mnemonic bip39.Mnemonic, passphrase string) (bip39.Seed, error) {
+	if _, err := DetectVersion(mnemonic); err != nil {
+		return nil, err
+	}
+	password := []byte(normalize(mnemonic.String()))
+	key := pbkdf2.Key(password, []byte("electrum"+normalize(passphrase)), iterations, SeedSize, sha512.New)
+	wipe.Bytes(password)
+	return key, nil
+}
+
+// NewMnemonic generates a new 12-word mnemonic of the given seed version and language using entropy from rand.
+// The mnemonic is chosen such that it is not a valid BIP-39 mnemonic at the same time.
+func NewMnemonic(rand io.Reader, version Version, language string) (bip39.Mnemonic, error) {
+	prefix, ok := prefixes[version]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidVersion, version)
+	}
+	newList, ok := languages[language]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedLanguage, language)
+	}
+	list := newList()
+	codec := bip39.NewCodec(list)
+
+	// the entropy must be large enough to produce all words
+	lowerBound := new(big.Int).Lsh(bigOne, entropyBits-wordlist.IndexBits)
+	buf := make([]byte, (entropyBits+7)/8)
+	defer wipe.Bytes(buf)
+	entropy := new(big.Int)
+	for entropy.Cmp(lowerBound) < 0 {
+		if _, err := io.ReadFull(rand, buf); err != nil {
+			return nil, err
+		}
+		buf[0] &= 0xff >> (8*len(buf) - entropyBits)
+		entropy.SetBytes(buf)
+	}
+
+	// increment the entropy until the resulting mnemonic has the correct version
+	for {
+		entropy.Add(entropy, bigOne)
+		mnemonic := encode(list, entropy)
+		// avoid mnemonics that also have a valid BIP-39 checksum
+		if _, err := codec.MnemonicToEntropy(mnemonic); err == nil {
+			continue
+		}
+		if strings.HasPrefix(versionHash(mnemonic), prefix) {
+			entropy.SetInt64(0)
+			return mnemonic, nil
+		}
+	}
+}
+
+var bigOne = big.NewInt(1)
+
+// versionHash returns the hex encoded HMAC-SHA512 of the normalized mnemonic, whose prefix determines the version.
+func versionHash(mnemonic bip39.Mnemonic) string {
+	mac := hmac.New(sha512.New, []byte("Seed version"))
+	mac.Write([]byte(normalize(mnemonic.String())))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// encode converts the number into words, starting with the least significant 11 bits.
+func encode(list wordlist.List, i *big.Int) bip39.Mnemonic {
+	var words bip39.Mnemonic
+	n := new(big.Int).Set(i)
+	index := new(big.Int)
+	count := big.NewInt(wordlist.Count)
+	for n.Sign() > 0 {
+		n.DivMod(n, count, index)
+		words = append(words, list.Word(int(index.Int64())))
+	}
+	return words
+}
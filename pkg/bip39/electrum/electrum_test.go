@@ -0,0 +1,142 @@
+package electrum_test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wollac/iota-crypto-demo/internal/hexutil"
+	"github.com/wollac/iota-crypto-demo/pkg/bip39"
+	"github.com/wollac/iota-crypto-demo/pkg/bip39/electrum"
+)
+
+// test vectors taken from the Electrum test suite
+var tests = []*struct {
+	mnemonic   string
+	passphrase string
+	version    electrum.Version
+	seed       string
+}{
+	{
+		"wild father tree among universe such mobile favorite target dynamic credit identify",
+		"",
+		electrum.Segwit,
+		"aac2a6302e48577ab4b46f23dbae0774e2e62c796f797d0a1b5faeb528301e3064342dafb79069e7c4c6b8c38ae11d7a973bec0d4f70626f8cc5184a8d0b0756",
+	},
+	{
+		"wild father tree among universe such mobile favorite target dynamic credit identify",
+		"Did you ever hear the tragedy of Darth Plagueis the Wise?",
+		electrum.Segwit,
+		"4aa29f2aeb0127efb55138ab9e7be83b36750358751906f86c662b21a1ea1370f949e6d1a12fa56d3d93cadda93038c76ac8118597364e46f5156fde6183c82f",
+	},
+	{
+		"bitter grass shiver impose acquire brush forget axis eager alone wine silver",
+		"",
+		electrum.Segwit,
+		"8ff3b1fa35d0bace7e80255253ee1ada21586eccd341bb90ae8ff5a7214e7d62b2be30df64807fd8716e604c66da392834853644bffdab8a7d9ac029f52a9a8c",
+	},
+	{
+		"cycle rocket west magnet parrot shuffle foot correct salt library feed song",
+		"",
+		electrum.Standard,
+		"",
+	},
+	{
+		"almíbar tibio superar vencer hacha peatón príncipe matar consejo polen vehículo odisea",
+		"",
+		electrum.Standard,
+		"18bffd573a960cc775bbd80ed60b7dc00bc8796a186edebe7fc7cf1f316da0fe937852a969c5c79ded8255cdf54409537a16339fbe33fb9161af793ea47faa7a",
+	},
+	{
+		"equipo fiar auge langosta hacha calor trance cubrir carro pulmón oro áspero",
+		"",
+		electrum.Segwit,
+		"001ebce6bfde5851f28a0d44aae5ae0c762b600daf3b33fc8fc630aee0d207646b6f98b18e17dfe3be0a5efe2753c7cdad95860adbbb62cecad4dedb88e02a64",
+	},
+}
+
+func TestDetectVersion(t *testing.T) {
+	for _, tt := range tests {
+		t.Run(tt.mnemonic, func(t *testing.T) {
+			version, err := electrum.DetectVersion(bip39.ParseMnemonic(tt.mnemonic))
+			require.NoError(t, err)
+			assert.Equal(t, tt.version, version)
+
+			// the version does not depend on case, accents or white space
+			version, err = electrum.DetectVersion(bip39.ParseMnemonic(" " + strings.ToUpper(tt.mnemonic) + "\n"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.version, version)
+		})
+	}
+}
+
+func TestDetectVersionInvalid(t *testing.T) {
+	for _, mnemonic := range []string{
+		// BIP-39 mnemonic
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		// Electrum two-factor authentication seed
+		"science dawn member doll dutch real can brick knife deny drive list",
+	} {
+		_, err := electrum.DetectVersion(bip39.ParseMnemonic(mnemonic))
+		assert.ErrorIs(t, err, electrum.ErrInvalidMnemonic)
+		_, err = electrum.MnemonicToSeed(bip39.ParseMnemonic(mnemonic), "")
+		assert.ErrorIs(t, err, electrum.ErrInvalidMnemonic)
+	}
+}
+
+func TestMnemonicToSeed(t *testing.T) {
+	for _, tt := range tests {
+		if len(tt.seed) == 0 {
+			continue
+		}
+		t.Run(tt.mnemonic, func(t *testing.T) {
+			seed, err := electrum.MnemonicToSeed(bip39.ParseMnemonic(tt.mnemonic), tt.passphrase)
+			require.NoError(t, err)
+			assert.EqualValues(t, hexutil.MustDecodeString(tt.seed), seed)
+
+			// the passphrase is normalized like the mnemonic
+			seed, err = electrum.MnemonicToSeed(bip39.ParseMnemonic(tt.mnemonic), strings.ToUpper(tt.passphrase))
+			require.NoError(t, err)
+			assert.EqualValues(t, hexutil.MustDecodeString(tt.seed), seed)
+		})
+	}
+}
+
+func TestNewMnemonic(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	for _, language := range []string{"english", "spanish", "japanese", "chinese_simplified"} {
+		codec, err := bip39.NewCodecForLanguage(language)
+		require.NoError(t, err)
+		for _, version := range []electrum.Version{electrum.Standard, electrum.Segwit} {
+			t.Run(language+"/"+version.String(), func(t *testing.T) {
+				mnemonic, err := electrum.NewMnemonic(rng, version, language)
+				require.NoError(t, err)
+				assert.Len(t, mnemonic, 12)
+
+				detected, err := electrum.DetectVersion(mnemonic)
+				require.NoError(t, err)
+				assert.Equal(t, version, detected)
+
+				// the mnemonic must not be a valid BIP-39 mnemonic
+				_, err = codec.MnemonicToEntropy(mnemonic)
+				assert.ErrorIs(t, err, bip39.ErrInvalidChecksum)
+
+				// the mnemonic can be parsed from its string representation
+				detected, err = electrum.DetectVersion(bip39.ParseMnemonic(mnemonic.String()))
+				require.NoError(t, err)
+				assert.Equal(t, version, detected)
+			})
+		}
+	}
+}
+
+func TestNewMnemonicInvalid(t *testing.T) {
+	_, err := electrum.NewMnemonic(rand.New(rand.NewSource(0)), 0, "english")
+	assert.ErrorIs(t, err, electrum.ErrInvalidVersion)
+	_, err = electrum.NewMnemonic(rand.New(rand.NewSource(0)), electrum.Standard, "klingon")
+	assert.ErrorIs(t, err, electrum.ErrUnsupportedLanguage)
+	_, err = electrum.NewMnemonic(strings.NewReader(""), electrum.Standard, "english")
+	assert.Error(t, err)
+}
@@ -0,0 +1,58 @@
+package electrum
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// cjk contains the code point ranges Electrum treats as CJK characters, i.e. the CJK ideographs, radicals and
+// symbols, Japanese kana, Hangul, Bopomofo and Yi.
+var cjk = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x1100, Hi: 0x11ff, Stride: 1},
+		{Lo: 0x2e80, Hi: 0x2fdf, Stride: 1},
+		{Lo: 0x2ff0, Hi: 0x31ef, Stride: 1},
+		{Lo: 0x31f0, Hi: 0x4dbf, Stride: 1},
+		{Lo: 0x4dc0, Hi: 0x9fff, Stride: 1},
+		{Lo: 0xa000, Hi: 0xa4cf, Stride: 1},
+		{Lo: 0xa960, Hi: 0xa97f, Stride: 1},
+		{Lo: 0xac00, Hi: 0xd7ff, Stride: 1},
+		{Lo: 0xf900, Hi: 0xfaff, Stride: 1},
+		{Lo: 0xfe30, Hi: 0xfe4f, Stride: 1},
+		{Lo: 0xff00, Hi: 0xffef, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1b000, Hi: 0x1b0ff, Stride: 1},
+		{Lo: 0x20000, Hi: 0x2a6df, Stride: 1},
+		{Lo: 0x2a700, Hi: 0x2b81f, Stride: 1},
+		{Lo: 0x2f800, Hi: 0x2fa1f, Stride: 1},
+	},
+}
+
+// normalize converts the text into the canonical form that is used for the version check and the seed derivation:
+// The text is NFKD normalized and converted to lower case, all combining characters (e.g. accents) are removed and
+// words are separated by a single space, unless both of the adjacent characters are CJK characters.
+func normalize(text string) string {
+	text = strings.ToLower(norm.NFKD.String(text))
+	text = strings.Map(func(r rune) rune {
+		if norm.NFKD.PropertiesString(string(r)).CCC() != 0 {
+			return -1
+		}
+		return r
+	}, text)
+
+	var sb strings.Builder
+	var last rune
+	for i, field := range strings.Fields(text) {
+		first, _ := utf8.DecodeRuneInString(field)
+		if i > 0 && !(unicode.Is(cjk, last) && unicode.Is(cjk, first)) {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(field)
+		last, _ = utf8.DecodeLastRuneInString(field)
+	}
+	return sb.String()
+}
@@ -0,0 +1,12 @@
+package wordlists
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChineseSimplified(t *testing.T) {
+	assert.NotPanics(t, func() { ChineseSimplified() })
+	testWordListHash(t, chineseSimplified, "chinese_simplified.txt")
+}
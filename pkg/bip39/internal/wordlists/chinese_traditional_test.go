@@ -0,0 +1,12 @@
+package wordlists
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChineseTraditional(t *testing.T) {
+	assert.NotPanics(t, func() { ChineseTraditional() })
+	testWordListHash(t, chineseTraditional, "chinese_traditional.txt")
+}
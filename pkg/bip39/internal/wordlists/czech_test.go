@@ -0,0 +1,12 @@
+package wordlists
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCzech(t *testing.T) {
+	assert.NotPanics(t, func() { Czech() })
+	testWordListHash(t, czech, "czech.txt")
+}
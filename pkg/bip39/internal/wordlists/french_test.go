@@ -0,0 +1,12 @@
+package wordlists
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrench(t *testing.T) {
+	assert.NotPanics(t, func() { French() })
+	testWordListHash(t, french, "french.txt")
+}
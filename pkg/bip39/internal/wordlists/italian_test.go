@@ -0,0 +1,12 @@
+package wordlists
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItalian(t *testing.T) {
+	assert.NotPanics(t, func() { Italian() })
+	testWordListHash(t, italian, "italian.txt")
+}
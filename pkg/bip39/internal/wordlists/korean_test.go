@@ -0,0 +1,12 @@
+package wordlists
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKorean(t *testing.T) {
+	assert.NotPanics(t, func() { Korean() })
+	testWordListHash(t, korean, "korean.txt")
+}
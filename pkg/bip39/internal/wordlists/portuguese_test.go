@@ -0,0 +1,12 @@
+package wordlists
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPortuguese(t *testing.T) {
+	assert.NotPanics(t, func() { Portuguese() })
+	testWordListHash(t, portuguese, "portuguese.txt")
+}
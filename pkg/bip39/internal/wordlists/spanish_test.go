@@ -0,0 +1,12 @@
+package wordlists
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpanish(t *testing.T) {
+	assert.NotPanics(t, func() { Spanish() })
+	testWordListHash(t, spanish, "spanish.txt")
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/wollac/iota-crypto-demo/pkg/bip39/wordlist"
+	"golang.org/x/text/unicode/norm"
 )
 
 type wordList struct {
@@ -13,7 +14,8 @@ type wordList struct {
 }
 
 // newWordList creates a wordList from a white space separate list of words.
-// This function will panic if the word count is not 2048 or if there are duplicate words.
+// This function will panic if the word count is not 2048, if there are duplicate words or if a word is not in NFKD.
+// Mnemonics are always NFKD normalized, so words in any other normalization form could never be matched.
 func newWordList(s string) wordlist.List {
 	fields := strings.Fields(s)
 	if l := len(fields); l != wordlist.Count {
@@ -22,6 +24,9 @@ func newWordList(s string) wordlist.List {
 
 	indexMap := make(map[string]int, wordlist.Count)
 	for i, word := range fields {
+		if !norm.NFKD.IsNormalString(word) {
+			panic("word not NFKD normalized: " + word)
+		}
 		if _, contains := indexMap[word]; contains {
 			panic("duplicate word: " + word)
 		}
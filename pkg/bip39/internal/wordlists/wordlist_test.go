@@ -1,6 +1,7 @@
 package wordlists
 
 import (
+	"hash/crc32"
 	"io"
 	"net/http"
 	"testing"
@@ -22,3 +23,28 @@ func testWordListHash(t *testing.T, list string, name string) {
 	referenceList := string(body)
 	assert.Equal(t, referenceList, list, "word lists do not match")
 }
+
+func TestWordListChecksum(t *testing.T) {
+	// CRC-32 checksums of the reference files, these can be verified without network access
+	var tests = []*struct {
+		name     string
+		list     string
+		checksum uint32
+	}{
+		{"english", english, 0xc1dbd296},
+		{"japanese", japanese, 0x0acc1419},
+		{"spanish", spanish, 0x266e4f3d},
+		{"french", french, 0x3e56b216},
+		{"italian", italian, 0x2fc7d07e},
+		{"czech", czech, 0xd1b5fda0},
+		{"korean", korean, 0x4ef461eb},
+		{"chinese_simplified", chineseSimplified, 0xe3721bbf},
+		{"chinese_traditional", chineseTraditional, 0x3c20b443},
+		{"portuguese", portuguese, 0xe627a546},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.checksum, crc32.ChecksumIEEE([]byte(tt.list)))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package bip39
 
 import (
 	"strings"
+	"unicode"
 
 	"golang.org/x/text/unicode/norm"
 )
@@ -10,9 +11,22 @@ import (
 type Mnemonic []string
 
 // ParseMnemonic parses s as white space separated list of mnemonic words.
+// As each word of the Chinese word lists consists of a single Han character, Chinese mnemonics may also be written
+// without any separators.
 func ParseMnemonic(s string) Mnemonic {
 	normalized := norm.NFKD.String(s)
-	return strings.Fields(normalized)
+	fields := strings.Fields(normalized)
+	words := make(Mnemonic, 0, len(fields))
+	for _, field := range fields {
+		if !isHan(field) {
+			words = append(words, field)
+			continue
+		}
+		for _, r := range field {
+			words = append(words, string(r))
+		}
+	}
+	return words
 }
 
 // String will return all the words composing the mnemonic sentence as a single string of space separated words.
@@ -30,3 +44,13 @@ func (ms *Mnemonic) UnmarshalText(text []byte) error {
 	*ms = ParseMnemonic(string(text))
 	return nil
 }
+
+// isHan returns whether s only consists of Han characters.
+func isHan(s string) bool {
+	for _, r := range s {
+		if !unicode.Is(unicode.Han, r) {
+			return false
+		}
+	}
+	return true
+}
@@ -21,6 +21,8 @@ var parsePathTests = []*struct {
 	{"こんにちは 世界！", Mnemonic{"こんにちは", "世界!"}},
 	{"Süßölgefäß", Mnemonic{"Süßölgefäß"}},
 	{"あいこくしん　あおぞら", Mnemonic{"あいこくしん", "あおぞら"}},
+	{"的一是在", Mnemonic{"的", "一", "是", "在"}},
+	{"的一 是　在", Mnemonic{"的", "一", "是", "在"}},
 	{strings.Repeat("a ", 15), Mnemonic{"a", "a", "a", "a", "a", "a", "a", "a", "a", "a", "a", "a", "a", "a", "a"}},
 }
 
@@ -57,6 +59,7 @@ var mnemonicStringTests = []*struct {
 	{"こんにちは 世界！", "こんにちは 世界!"},
 	{"Süßölgefäß", "Süßölgefäß"},
 	{"あいこくしん　あおぞら", "あいこくしん あおぞら"},
+	{"的一是在", "的 一 是 在"},
 }
 
 func TestMnemonicString(t *testing.T) {
@@ -0,0 +1,82 @@
+package recovery
+
+// costs of the edit operations, a typo of a neighboring key is considered more likely than any other substitution
+const (
+	costEdit     = 2
+	costNeighbor = 1
+)
+
+// qwerty contains the rows of the QWERTY keyboard layout.
+var qwerty = [...]string{"qwertyuiop", "asdfghjkl", "zxcvbnm"}
+
+// keyPositions maps each letter to its row and column on the keyboard.
+var keyPositions = func() map[rune][2]int {
+	m := make(map[rune][2]int)
+	for row, keys := range qwerty {
+		for col, key := range keys {
+			m[key] = [2]int{row, col}
+		}
+	}
+	return m
+}()
+
+// neighbors reports whether a and b are adjacent keys on the keyboard.
+func neighbors(a, b rune) bool {
+	pa, okA := keyPositions[a]
+	pb, okB := keyPositions[b]
+	if !okA || !okB {
+		return false
+	}
+	dr, dc := pa[0]-pb[0], pa[1]-pb[1]
+	// the rows are staggered to the right, so that a key touches the keys in the same and the next column above it
+	switch dr {
+	case 0:
+		return dc == -1 || dc == 1
+	case 1:
+		return dc == 0 || dc == -1
+	case -1:
+		return dc == 0 || dc == 1
+	default:
+		return false
+	}
+}
+
+// distance returns the weighted edit distance between a and b. It counts insertions, deletions, substitutions and
+// transpositions of adjacent characters, where substitutions of neighboring keys have a reduced cost.
+func distance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	// d[i][j] is the distance between the first i runes of s and the first j runes of t
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i * costEdit
+	}
+	for j := range d[0] {
+		d[0][j] = j * costEdit
+	}
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			sub := 0
+			if s[i-1] != t[j-1] {
+				sub = costEdit
+				if neighbors(s[i-1], t[j-1]) {
+					sub = costNeighbor
+				}
+			}
+			d[i][j] = min(d[i-1][j]+costEdit, d[i][j-1]+costEdit, d[i-1][j-1]+sub)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+costEdit)
+			}
+		}
+	}
+	return d[len(s)][len(t)]
+}
+
+func min(x int, ys ...int) int {
+	for _, y := range ys {
+		if y < x {
+			x = y
+		}
+	}
+	return x
+}
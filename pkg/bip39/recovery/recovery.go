@@ -0,0 +1,260 @@
+/*
+Package recovery helps to restore BIP-39 mnemonics that have been written down or typed incorrectly.
+
+It suggests the most likely words for unknown words based on their edit distance, where typos of neighboring keys
+on a QWERTY keyboard are considered more likely, and resolves words abbreviated to their unique first four letters.
+Furthermore, it lists all final words that complete a mnemonic with a valid checksum and searches for all valid
+mnemonics that differ from a given one by a single replaced or missing word.
+
+The checksum of a mnemonic only consists of 4 to 8 bits, so that a search usually finds many valid candidates.
+These are sorted by their likelihood, but without further information, e.g. a known address, it cannot be decided
+which of them is the correct one.
+*/
+package recovery
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/wollac/iota-crypto-demo/internal/wipe"
+	"github.com/wollac/iota-crypto-demo/pkg/bip39"
+	"github.com/wollac/iota-crypto-demo/pkg/bip39/wordlist"
+)
+
+// PrefixLength is the number of leading characters that uniquely identify a word in the official word lists.
+const PrefixLength = 4
+
+// supported number of words corresponding to 128 to 512 bits of entropy
+const (
+	minWords = 12
+	maxWords = 48
+)
+
+var (
+	// ErrTooManyUnknownWords is returned when a mnemonic contains more unknown words than the search can handle.
+	ErrTooManyUnknownWords = errors.New("too many unknown words")
+	// ErrSearchLimit is returned when a search would check more mnemonics than the given limit.
+	ErrSearchLimit = errors.New("search limit exceeded")
+)
+
+// Recoverer restores mnemonics for a single word list.
+type Recoverer struct {
+	codec *bip39.Codec
+	list  wordlist.List
+}
+
+// New creates a new Recoverer using the word list of codec.
+func New(codec *bip39.Codec) *Recoverer {
+	return &Recoverer{codec: codec, list: codec.WordList()}
+}
+
+// UnknownWords returns the positions of all words of the mnemonic that are not contained in the word list.
+func (r *Recoverer) UnknownWords(mnemonic bip39.Mnemonic) []int {
+	var unknown []int
+	for i, word := range mnemonic {
+		if !r.list.Contains(word) {
+			unknown = append(unknown, i)
+		}
+	}
+	return unknown
+}
+
+// ResolvePrefix returns the unique word of the word list that starts with word or with its first PrefixLength
+// characters. It reports false, if there is no such word or if it is not unique.
+func (r *Recoverer) ResolvePrefix(word string) (string, bool) {
+	if r.list.Contains(word) {
+		return word, true
+	}
+	if w, ok := r.uniquePrefix(word); ok {
+		return w, true
+	}
+	if runes := []rune(word); len(runes) > PrefixLength {
+		return r.uniquePrefix(string(runes[:PrefixLength]))
+	}
+	return "", false
+}
+
+func (r *Recoverer) uniquePrefix(prefix string) (string, bool) {
+	var match string
+	for i := 0; i < wordlist.Count; i++ {
+		if w := r.list.Word(i); strings.HasPrefix(w, prefix) {
+			if match != "" {
+				return "", false
+			}
+			match = w
+		}
+	}
+	return match, match != ""
+}
+
+// Suggest returns up to n words of the word list that are the most likely replacements for word, the best first.
+// A word resolved by ResolvePrefix is always the first suggestion.
+func (r *Recoverer) Suggest(word string, n int) []string {
+	words := r.rank(word)
+	if len(words) > n {
+		words = words[:n]
+	}
+	return words
+}
+
+// rank returns all words of the word list sorted by their distance to word.
+func (r *Recoverer) rank(word string) []string {
+	resolved, _ := r.ResolvePrefix(word)
+
+	type candidate struct {
+		word     string
+		distance int
+	}
+	candidates := make([]candidate, wordlist.Count)
+	for i := range candidates {
+		w := r.list.Word(i)
+		d := distance(word, w)
+		if w == resolved {
+			d = -1
+		}
+		candidates[i] = candidate{w, d}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].distance < candidates[j].distance })
+
+	words := make([]string, len(candidates))
+	for i := range candidates {
+		words[i] = candidates[i].word
+	}
+	return words
+}
+
+// CompleteLastWord returns all words of the word list that, when appended to the given words, yield a mnemonic with
+// a valid checksum.
+func (r *Recoverer) CompleteLastWord(words bip39.Mnemonic) ([]string, error) {
+	if unknown := r.UnknownWords(words); len(unknown) > 0 {
+		return nil, fmt.Errorf("%w: invalid word (%s)", bip39.ErrInvalidMnemonic, words[unknown[0]])
+	}
+	mnemonic := append(append(bip39.Mnemonic{}, words...), "")
+	if err := r.validLength(len(mnemonic)); err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for i := 0; i < wordlist.Count; i++ {
+		mnemonic[len(mnemonic)-1] = r.list.Word(i)
+		if r.valid(mnemonic) {
+			result = append(result, mnemonic[len(mnemonic)-1])
+		}
+	}
+	return result, nil
+}
+
+// RecoverReplaced searches for all mnemonics with a valid checksum that differ from the given one in exactly one
+// word or by two swapped adjacent words. If the mnemonic contains an unknown word, only this word is replaced.
+// The candidates are sorted by their likelihood. At most limit mnemonics are checked, otherwise ErrSearchLimit is
+// returned.
+func (r *Recoverer) RecoverReplaced(mnemonic bip39.Mnemonic, limit int) ([]bip39.Mnemonic, error) {
+	if err := r.validLength(len(mnemonic)); err != nil {
+		return nil, err
+	}
+
+	positions := r.UnknownWords(mnemonic)
+	if len(positions) > 1 {
+		return nil, fmt.Errorf("%w: %d", ErrTooManyUnknownWords, len(positions))
+	}
+	// if all words are known, any of them can be wrong, and two adjacent words might have been swapped
+	var swaps int
+	if len(positions) == 0 {
+		positions = make([]int, len(mnemonic))
+		for i := range positions {
+			positions[i] = i
+		}
+		swaps = len(mnemonic) - 1
+	}
+	if checks := len(positions)*(wordlist.Count-1) + swaps; checks > limit {
+		return nil, fmt.Errorf("%w: %d mnemonics", ErrSearchLimit, checks)
+	}
+
+	var result []bip39.Mnemonic
+	// swapping two adjacent words is the most likely mistake
+	for i := 0; i < swaps; i++ {
+		if mnemonic[i] == mnemonic[i+1] {
+			continue
+		}
+		candidate := append(bip39.Mnemonic{}, mnemonic...)
+		candidate[i], candidate[i+1] = candidate[i+1], candidate[i]
+		if r.valid(candidate) {
+			result = append(result, candidate)
+		}
+	}
+
+	type replacement struct {
+		mnemonic bip39.Mnemonic
+		rank     int
+	}
+	var replacements []replacement
+	for _, pos := range positions {
+		for rank, word := range r.rank(mnemonic[pos]) {
+			if word == mnemonic[pos] {
+				continue
+			}
+			candidate := append(bip39.Mnemonic{}, mnemonic...)
+			candidate[pos] = word
+			if r.valid(candidate) {
+				replacements = append(replacements, replacement{candidate, rank})
+			}
+		}
+	}
+	// the more similar the replaced word, the more likely the candidate
+	sort.SliceStable(replacements, func(i, j int) bool { return replacements[i].rank < replacements[j].rank })
+	for _, c := range replacements {
+		result = append(result, c.mnemonic)
+	}
+	return result, nil
+}
+
+// RecoverMissing searches for all mnemonics with a valid checksum that are obtained by inserting a single word
+// at any position of the given mnemonic. At most limit mnemonics are checked, otherwise ErrSearchLimit is returned.
+func (r *Recoverer) RecoverMissing(mnemonic bip39.Mnemonic, limit int) ([]bip39.Mnemonic, error) {
+	if unknown := r.UnknownWords(mnemonic); len(unknown) > 0 {
+		return nil, fmt.Errorf("%w: invalid word (%s)", bip39.ErrInvalidMnemonic, mnemonic[unknown[0]])
+	}
+	if err := r.validLength(len(mnemonic) + 1); err != nil {
+		return nil, err
+	}
+	if checks := (len(mnemonic) + 1) * wordlist.Count; checks > limit {
+		return nil, fmt.Errorf("%w: %d mnemonics", ErrSearchLimit, checks)
+	}
+
+	var result []bip39.Mnemonic
+	seen := make(map[string]bool)
+	for pos := 0; pos <= len(mnemonic); pos++ {
+		for i := 0; i < wordlist.Count; i++ {
+			candidate := make(bip39.Mnemonic, 0, len(mnemonic)+1)
+			candidate = append(candidate, mnemonic[:pos]...)
+			candidate = append(candidate, r.list.Word(i))
+			candidate = append(candidate, mnemonic[pos:]...)
+			// inserting a word next to an equal word leads to the same mnemonic
+			if key := candidate.String(); !seen[key] && r.valid(candidate) {
+				seen[key] = true
+				result = append(result, candidate)
+			}
+		}
+	}
+	return result, nil
+}
+
+// validLength checks whether a mnemonic with n words is supported.
+func (r *Recoverer) validLength(n int) error {
+	if n%3 != 0 || n < minWords || n > maxWords {
+		return fmt.Errorf("%w: unsupported word count (%d)", bip39.ErrInvalidMnemonic, n)
+	}
+	return nil
+}
+
+// valid reports whether the mnemonic has a valid checksum.
+func (r *Recoverer) valid(mnemonic bip39.Mnemonic) bool {
+	entropy, err := r.codec.MnemonicToEntropy(mnemonic)
+	if err != nil {
+		return false
+	}
+	wipe.Bytes(entropy)
+	return true
+}
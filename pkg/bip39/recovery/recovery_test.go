@@ -0,0 +1,149 @@
+package recovery_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wollac/iota-crypto-demo/pkg/bip39"
+	"github.com/wollac/iota-crypto-demo/pkg/bip39/recovery"
+)
+
+const mnemonic = "legal winner thank year wave sausage worth useful legal winner thank yellow"
+
+func newRecoverer(t *testing.T) (*recovery.Recoverer, *bip39.Codec) {
+	c, err := bip39.NewCodecForLanguage("english")
+	require.NoError(t, err)
+	return recovery.New(c), c
+}
+
+func TestUnknownWords(t *testing.T) {
+	r, _ := newRecoverer(t)
+	assert.Empty(t, r.UnknownWords(bip39.ParseMnemonic(mnemonic)))
+	assert.Equal(t, []int{1, 4}, r.UnknownWords(bip39.ParseMnemonic("legal winer thank year wabe sausage")))
+}
+
+func TestResolvePrefix(t *testing.T) {
+	r, _ := newRecoverer(t)
+	var tests = []*struct {
+		word     string
+		expected string
+		ok       bool
+	}{
+		{"abandon", "abandon", true},
+		{"aban", "abandon", true},
+		{"abando", "abandon", true},
+		{"abandno", "abandon", true},
+		{"act", "act", true},
+		{"acti", "action", true},
+		{"ab", "", false},
+		{"xyz", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			word, ok := r.ResolvePrefix(tt.word)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, word)
+		})
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	r, _ := newRecoverer(t)
+	var tests = []*struct {
+		word     string
+		expected string
+	}{
+		{"abandno", "abandon"}, // resolved prefix
+		{"wace", "wave"},       // neighboring key
+		{"wvae", "wave"},       // transposition
+		{"sausge", "sausage"},  // deletion
+		{"yelloww", "yellow"},  // insertion
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			suggestions := r.Suggest(tt.word, 3)
+			require.Len(t, suggestions, 3)
+			assert.Equal(t, tt.expected, suggestions[0])
+		})
+	}
+}
+
+func TestCompleteLastWord(t *testing.T) {
+	r, c := newRecoverer(t)
+	var tests = []*struct {
+		words    int
+		count    int
+		expected string
+	}{
+		{11, 128, "about"},
+		{23, 8, "art"},
+	}
+	for _, tt := range tests {
+		words := bip39.ParseMnemonic(strings.Repeat("abandon ", tt.words))
+		completions, err := r.CompleteLastWord(words)
+		require.NoError(t, err)
+		assert.Len(t, completions, tt.count)
+		assert.Contains(t, completions, tt.expected)
+		for _, word := range completions {
+			_, err := c.MnemonicToEntropy(append(words, word))
+			assert.NoError(t, err)
+		}
+	}
+
+	_, err := r.CompleteLastWord(bip39.ParseMnemonic(strings.Repeat("abandon ", 12)))
+	assert.ErrorIs(t, err, bip39.ErrInvalidMnemonic)
+}
+
+func TestRecoverReplaced(t *testing.T) {
+	r, c := newRecoverer(t)
+	expected := bip39.ParseMnemonic(mnemonic)
+
+	t.Run("unknown word", func(t *testing.T) {
+		candidates, err := r.RecoverReplaced(bip39.ParseMnemonic(strings.Replace(mnemonic, "wave", "wace", 1)), 10000)
+		require.NoError(t, err)
+		require.NotEmpty(t, candidates)
+		assert.Equal(t, expected, candidates[0])
+	})
+	t.Run("wrong word", func(t *testing.T) {
+		candidates, err := r.RecoverReplaced(bip39.ParseMnemonic(strings.Replace(mnemonic, "wave", "wage", 1)), 100000)
+		require.NoError(t, err)
+		assert.Contains(t, candidates, expected)
+		for _, candidate := range candidates {
+			_, err := c.MnemonicToEntropy(candidate)
+			assert.NoError(t, err)
+		}
+	})
+	t.Run("swapped words", func(t *testing.T) {
+		candidates, err := r.RecoverReplaced(bip39.ParseMnemonic(strings.Replace(mnemonic, "year wave", "wave year", 1)), 100000)
+		require.NoError(t, err)
+		assert.Contains(t, candidates, expected)
+	})
+	t.Run("limit", func(t *testing.T) {
+		_, err := r.RecoverReplaced(bip39.ParseMnemonic(strings.Replace(mnemonic, "wave", "wage", 1)), 10000)
+		assert.ErrorIs(t, err, recovery.ErrSearchLimit)
+	})
+	t.Run("unknown words", func(t *testing.T) {
+		_, err := r.RecoverReplaced(bip39.ParseMnemonic(strings.Replace(mnemonic, "legal", "legl", 2)), 100000)
+		assert.ErrorIs(t, err, recovery.ErrTooManyUnknownWords)
+	})
+}
+
+func TestRecoverMissing(t *testing.T) {
+	r, c := newRecoverer(t)
+	expected := bip39.ParseMnemonic(mnemonic)
+
+	candidates, err := r.RecoverMissing(bip39.ParseMnemonic(strings.Replace(mnemonic, "wave ", "", 1)), 100000)
+	require.NoError(t, err)
+	assert.Contains(t, candidates, expected)
+	for _, candidate := range candidates {
+		_, err := c.MnemonicToEntropy(candidate)
+		assert.NoError(t, err)
+	}
+
+	_, err = r.RecoverMissing(expected[:11], 1000)
+	assert.ErrorIs(t, err, recovery.ErrSearchLimit)
+	_, err = r.RecoverMissing(expected[:10], 100000)
+	assert.ErrorIs(t, err, bip39.ErrInvalidMnemonic)
+}
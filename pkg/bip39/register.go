@@ -2,25 +2,74 @@ package bip39
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 
 	"github.com/wollac/iota-crypto-demo/pkg/bip39/wordlist"
 )
 
+// mu guards the registered word lists and the cached codecs.
+var mu sync.Mutex
+
 var wordLists = make(map[string]func() wordlist.List)
 
-// SetWordList sets the list of words to use for mnemonics.
+// registeredCodecs caches a Codec for each registered word list, sorted by language.
+// It is nil when the codecs need to be rebuilt.
+var registeredCodecs []languageCodec
+
+type languageCodec struct {
+	language string
+	codec    *Codec
+}
+
+// SetWordList sets the list of words to use for mnemonics in the package-level functions.
 // The input must be the language key for a registered word list.
+// SetWordList must not be called concurrently with any other function of this package, use a Codec instead.
 func SetWordList(language string) error {
+	list, err := lookupWordList(language)
+	if err != nil {
+		return err
+	}
+	defaultCodec = NewCodec(list)
+	return nil
+}
+
+// lookupWordList returns a new instance of the word list registered for the given language.
+func lookupWordList(language string) (wordlist.List, error) {
+	mu.Lock()
 	init, ok := wordLists[language]
+	mu.Unlock()
 	if !ok {
-		return fmt.Errorf("word list '%s' is unavailable", language)
+		return nil, fmt.Errorf("word list '%s' is unavailable", language)
 	}
-	wordList = init()
-	return nil
+	return init(), nil
 }
 
 // RegisterWordList registers a function that returns a new instance of the given word list.
 // This is intended to be called from the init function in packages that implement word lists.
 func RegisterWordList(language string, init func() wordlist.List) {
+	mu.Lock()
+	defer mu.Unlock()
 	wordLists[language] = init
+	// rebuild the cached codecs on their next use
+	registeredCodecs = nil
+}
+
+// codecs returns a Codec for each registered word list sorted by language.
+// The codecs are only created once per set of registered word lists and must not be modified.
+func codecs() []languageCodec {
+	mu.Lock()
+	defer mu.Unlock()
+	if registeredCodecs != nil {
+		return registeredCodecs
+	}
+	lcs := make([]languageCodec, 0, len(wordLists))
+	for language, init := range wordLists {
+		lcs = append(lcs, languageCodec{language, NewCodec(init())})
+	}
+	sort.Slice(lcs, func(i, j int) bool {
+		return lcs[i].language < lcs[j].language
+	})
+	registeredCodecs = lcs
+	return registeredCodecs
 }
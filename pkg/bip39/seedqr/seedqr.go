@@ -0,0 +1,134 @@
+/*
+Package seedqr implements the Standard and Compact SeedQR formats for BIP-39 mnemonics.
+
+A Standard SeedQR contains the index of each word in the English word list as four decimal digits, which are
+encoded in numeric mode. A CompactSeedQR contains the entropy of the mnemonic encoded in byte mode.
+Both formats only support 12 and 24 word mnemonics and use the error correction level Low, resulting in QR codes of
+version 2 and 3 or version 1 and 2 respectively.
+
+The format is specified in https://github.com/SeedSigner/seedsigner/blob/dev/docs/seed_qr/README.md.
+*/
+package seedqr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wollac/iota-crypto-demo/internal/wipe"
+	"github.com/wollac/iota-crypto-demo/pkg/bip39"
+	"github.com/wollac/iota-crypto-demo/pkg/bip39/wordlist"
+	"github.com/wollac/iota-crypto-demo/pkg/qrcode"
+)
+
+// number of decimal digits encoding a word index in a Standard SeedQR
+const digitsPerWord = 4
+
+var (
+	// ErrInvalidLength is returned when a mnemonic or payload does not correspond to 12 or 24 words.
+	ErrInvalidLength = errors.New("invalid length")
+	// ErrInvalidPayload is returned when a Standard SeedQR payload contains invalid word indices.
+	ErrInvalidPayload = errors.New("invalid payload")
+)
+
+// SeedQR codes always use the English word list.
+var english = func() *bip39.Codec {
+	c, err := bip39.NewCodecForLanguage("english")
+	if err != nil {
+		panic(err)
+	}
+	return c
+}()
+
+// EncodeStandard returns the Standard SeedQR payload of the mnemonic.
+func EncodeStandard(mnemonic bip39.Mnemonic) (string, error) {
+	if err := validate(mnemonic); err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	for _, word := range mnemonic {
+		fmt.Fprintf(&sb, "%0*d", digitsPerWord, english.WordList().Index(word))
+	}
+	return sb.String(), nil
+}
+
+// DecodeStandard returns the mnemonic of the Standard SeedQR payload.
+func DecodeStandard(payload string) (bip39.Mnemonic, error) {
+	if n := len(payload); n != 12*digitsPerWord && n != 24*digitsPerWord {
+		return nil, fmt.Errorf("%w: payload of %d digits", ErrInvalidLength, n)
+	}
+	mnemonic := make(bip39.Mnemonic, len(payload)/digitsPerWord)
+	for i := range mnemonic {
+		digits := payload[i*digitsPerWord : (i+1)*digitsPerWord]
+		index, err := strconv.ParseUint(digits, 10, 16)
+		if err != nil || strings.IndexFunc(digits, isNotDigit) >= 0 || index >= wordlist.Count {
+			return nil, fmt.Errorf("%w: invalid word index %q", ErrInvalidPayload, digits)
+		}
+		mnemonic[i] = english.WordList().Word(int(index))
+	}
+	if err := validate(mnemonic); err != nil {
+		return nil, err
+	}
+	return mnemonic, nil
+}
+
+// EncodeCompact returns the CompactSeedQR payload of the mnemonic, i.e. its entropy.
+func EncodeCompact(mnemonic bip39.Mnemonic) ([]byte, error) {
+	if err := validateLength(len(mnemonic)); err != nil {
+		return nil, err
+	}
+	return english.MnemonicToEntropy(mnemonic)
+}
+
+// DecodeCompact returns the mnemonic of the CompactSeedQR payload.
+func DecodeCompact(payload []byte) (bip39.Mnemonic, error) {
+	// 16 and 32 bytes of entropy correspond to 12 and 24 words
+	if n := len(payload); n != 16 && n != 32 {
+		return nil, fmt.Errorf("%w: payload of %d bytes", ErrInvalidLength, n)
+	}
+	return english.EntropyToMnemonic(payload)
+}
+
+// Standard returns the Standard SeedQR code of the mnemonic.
+func Standard(mnemonic bip39.Mnemonic) (*qrcode.QRCode, error) {
+	payload, err := EncodeStandard(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	// the payload only consists of digits and is thus encoded in numeric mode
+	return qrcode.Encode(payload, qrcode.Low)
+}
+
+// Compact returns the CompactSeedQR code of the mnemonic.
+func Compact(mnemonic bip39.Mnemonic) (*qrcode.QRCode, error) {
+	payload, err := EncodeCompact(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	return qrcode.EncodeBytes(payload, qrcode.Low)
+}
+
+// validate checks that the mnemonic is a valid 12 or 24 word mnemonic using the English word list.
+func validate(mnemonic bip39.Mnemonic) error {
+	if err := validateLength(len(mnemonic)); err != nil {
+		return err
+	}
+	entropy, err := english.MnemonicToEntropy(mnemonic)
+	if err != nil {
+		return err
+	}
+	wipe.Bytes(entropy)
+	return nil
+}
+
+func validateLength(words int) error {
+	if words != 12 && words != 24 {
+		return fmt.Errorf("%w: %d words", ErrInvalidLength, words)
+	}
+	return nil
+}
+
+func isNotDigit(r rune) bool {
+	return r < '0' || r > '9'
+}
@@ -0,0 +1,120 @@
+package seedqr_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wollac/iota-crypto-demo/internal/hexutil"
+	"github.com/wollac/iota-crypto-demo/pkg/bip39"
+	"github.com/wollac/iota-crypto-demo/pkg/bip39/seedqr"
+)
+
+// examples published in the SeedQR specification
+var tests = []*struct {
+	mnemonic        string
+	standard        string
+	compact         string
+	standardVersion int
+	compactVersion  int
+}{
+	{
+		"forum undo fragile fade shy sign arrest garment culture tube off merit",
+		"073318950739065415961602009907670428187212261116",
+		"5bbd9d71a8ec7990831aff359d426545",
+		2, 1,
+	},
+	{
+		"attack pizza motion avocado network gather crop fresh patrol unusual wild holiday candy pony ranch winter theme error hybrid van cereal salon goddess expire",
+		"011513251154012711900771041507421289190620080870026613431420201617920614089619290300152408010643",
+		"0e74b64107f94cc0ccfae6a13dcbec3662154fec67e0e00999c07892597d190a",
+		3, 2,
+	},
+}
+
+func TestStandard(t *testing.T) {
+	for _, tt := range tests {
+		t.Run(tt.mnemonic, func(t *testing.T) {
+			mnemonic := bip39.ParseMnemonic(tt.mnemonic)
+			payload, err := seedqr.EncodeStandard(mnemonic)
+			require.NoError(t, err)
+			assert.Equal(t, tt.standard, payload)
+
+			decoded, err := seedqr.DecodeStandard(payload)
+			require.NoError(t, err)
+			assert.Equal(t, mnemonic, decoded)
+
+			q, err := seedqr.Standard(mnemonic)
+			require.NoError(t, err)
+			assert.Equal(t, tt.standardVersion, q.Version())
+		})
+	}
+}
+
+func TestCompact(t *testing.T) {
+	for _, tt := range tests {
+		t.Run(tt.mnemonic, func(t *testing.T) {
+			mnemonic := bip39.ParseMnemonic(tt.mnemonic)
+			payload, err := seedqr.EncodeCompact(mnemonic)
+			require.NoError(t, err)
+			assert.Equal(t, hexutil.MustDecodeString(tt.compact), payload)
+
+			decoded, err := seedqr.DecodeCompact(payload)
+			require.NoError(t, err)
+			assert.Equal(t, mnemonic, decoded)
+
+			q, err := seedqr.Compact(mnemonic)
+			require.NoError(t, err)
+			assert.Equal(t, tt.compactVersion, q.Version())
+		})
+	}
+}
+
+func TestEncodeInvalid(t *testing.T) {
+	var tests = []*struct {
+		mnemonic string
+		err      error
+	}{
+		{strings.Repeat("abandon ", 17) + "agent", seedqr.ErrInvalidLength},
+		{"forum undo fragile fade shy sign arrest garment culture tube off", seedqr.ErrInvalidLength},
+		{"forum undo fragile fade shy sign arrest garment culture tube off off", bip39.ErrInvalidChecksum},
+		{"forum undo fragile fade shy sign arrest garment culture tube off meritt", bip39.ErrInvalidMnemonic},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mnemonic, func(t *testing.T) {
+			mnemonic := bip39.ParseMnemonic(tt.mnemonic)
+			_, err := seedqr.EncodeStandard(mnemonic)
+			assert.ErrorIs(t, err, tt.err)
+			_, err = seedqr.EncodeCompact(mnemonic)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestDecodeStandardInvalid(t *testing.T) {
+	var tests = []*struct {
+		payload string
+		err     error
+	}{
+		{"", seedqr.ErrInvalidLength},
+		{"07331895073906541596160200990767042818721226111", seedqr.ErrInvalidLength},
+		{"073318950739065415961602009907670428187212262048", seedqr.ErrInvalidPayload},
+		{"0733189507390654159616020099076704281872122611+6", seedqr.ErrInvalidPayload},
+		{"07331895073906541596160200990767042818721226111a", seedqr.ErrInvalidPayload},
+		{"073318950739065415961602009907670428187212261117", bip39.ErrInvalidChecksum},
+	}
+	for _, tt := range tests {
+		t.Run(tt.payload, func(t *testing.T) {
+			_, err := seedqr.DecodeStandard(tt.payload)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestDecodeCompactInvalid(t *testing.T) {
+	for _, n := range []int{0, 15, 20, 24, 28, 33} {
+		_, err := seedqr.DecodeCompact(make([]byte, n))
+		assert.ErrorIs(t, err, seedqr.ErrInvalidLength)
+	}
+}
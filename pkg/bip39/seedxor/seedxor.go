@@ -0,0 +1,112 @@
+/*
+Package seedxor implements SeedXOR, splitting a BIP-39 mnemonic into several mnemonics of the same length.
+
+The entropy of the mnemonic is split into shares such that the XOR of the entropies of all shares equals the
+original entropy. Each share is itself a valid BIP-39 mnemonic with a correct checksum, and all shares are required
+to restore the original mnemonic. Mnemonics of 12, 18 and 24 words are supported.
+
+All mnemonics are encoded using the word list of the given bip39.Codec.
+
+The scheme is described in https://seedxor.com/.
+*/
+package seedxor
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/wollac/iota-crypto-demo/internal/wipe"
+	"github.com/wollac/iota-crypto-demo/pkg/bip39"
+)
+
+var (
+	// ErrInvalidLength is returned when a mnemonic does not consist of 12, 18 or 24 words.
+	ErrInvalidLength = errors.New("invalid mnemonic length")
+	// ErrInvalidShareCount is returned when trying to split into or combine less than two shares.
+	ErrInvalidShareCount = errors.New("invalid share count")
+	// ErrLengthMismatch is returned when combining shares of different lengths.
+	ErrLengthMismatch = errors.New("mismatching share lengths")
+)
+
+// minimum number of shares
+const minShareCount = 2
+
+// Split splits the mnemonic into n shares using randomness from rand and the word list of codec.
+// The first n-1 shares are random and the last share is the XOR of the mnemonic with all the other shares.
+func Split(rand io.Reader, codec *bip39.Codec, mnemonic bip39.Mnemonic, n int) ([]bip39.Mnemonic, error) {
+	if n < minShareCount {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidShareCount, n)
+	}
+	if err := validateLength(len(mnemonic)); err != nil {
+		return nil, err
+	}
+	last, err := codec.MnemonicToEntropy(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+	defer wipe.Bytes(last)
+
+	shares := make([]bip39.Mnemonic, 0, n)
+	entropy := make([]byte, len(last))
+	defer wipe.Bytes(entropy)
+	for i := 0; i < n-1; i++ {
+		if _, err := io.ReadFull(rand, entropy); err != nil {
+			return nil, err
+		}
+		share, err := codec.EntropyToMnemonic(entropy)
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, share)
+		xor(last, entropy)
+	}
+	share, err := codec.EntropyToMnemonic(last)
+	if err != nil {
+		return nil, err
+	}
+	return append(shares, share), nil
+}
+
+// Combine restores the mnemonic from all its shares using the word list of codec.
+// As the XOR of any mnemonics is a valid mnemonic, Combine cannot detect missing or wrong shares.
+func Combine(codec *bip39.Codec, shares []bip39.Mnemonic) (bip39.Mnemonic, error) {
+	if len(shares) < minShareCount {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidShareCount, len(shares))
+	}
+	var result []byte
+	defer func() { wipe.Bytes(result) }()
+	for i, share := range shares {
+		if err := validateLength(len(share)); err != nil {
+			return nil, fmt.Errorf("share %d: %w", i+1, err)
+		}
+		if len(share) != len(shares[0]) {
+			return nil, fmt.Errorf("%w: share %d has %d words instead of %d", ErrLengthMismatch, i+1, len(share), len(shares[0]))
+		}
+		entropy, err := codec.MnemonicToEntropy(share)
+		if err != nil {
+			return nil, fmt.Errorf("share %d: %w", i+1, err)
+		}
+		if result == nil {
+			result = entropy
+			continue
+		}
+		xor(result, entropy)
+		wipe.Bytes(entropy)
+	}
+	return codec.EntropyToMnemonic(result)
+}
+
+func validateLength(words int) error {
+	if words != 12 && words != 18 && words != 24 {
+		return fmt.Errorf("%w: %d words", ErrInvalidLength, words)
+	}
+	return nil
+}
+
+// xor sets dst to dst XOR src.
+func xor(dst, src []byte) {
+	for i := range dst {
+		dst[i] ^= src[i]
+	}
+}
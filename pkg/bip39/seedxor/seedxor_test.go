@@ -0,0 +1,105 @@
+package seedxor_test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wollac/iota-crypto-demo/pkg/bip39"
+	"github.com/wollac/iota-crypto-demo/pkg/bip39/seedxor"
+)
+
+// example published on https://seedxor.com/
+var (
+	exampleShares = []string{
+		"romance wink lottery autumn shop bring dawn tongue range crater truth ability miss spice fitness easy legal release recall obey exchange recycle dragon room",
+		"lion misery divide hurry latin fluid camp advance illegal lab pyramid unaware eager fringe sick camera series noodle toy crowd jeans select depth lounge",
+		"vault nominee cradle silk own frown throw leg cactus recall talent worry gadget surface shy planet purpose coffee drip few seven term squeeze educate",
+	}
+	exampleMnemonic = "silent toe meat possible chair blossom wait occur this worth option bag nurse find fish scene bench asthma bike wage world quit primary indoor"
+)
+
+func newCodec(t *testing.T, language string) *bip39.Codec {
+	codec, err := bip39.NewCodecForLanguage(language)
+	require.NoError(t, err)
+	return codec
+}
+
+func TestCombine(t *testing.T) {
+	shares := make([]bip39.Mnemonic, len(exampleShares))
+	for i := range exampleShares {
+		shares[i] = bip39.ParseMnemonic(exampleShares[i])
+	}
+	english := newCodec(t, "english")
+	mnemonic, err := seedxor.Combine(english, shares)
+	require.NoError(t, err)
+	assert.Equal(t, bip39.ParseMnemonic(exampleMnemonic), mnemonic)
+
+	// the order of the shares does not matter
+	mnemonic, err = seedxor.Combine(english, []bip39.Mnemonic{shares[2], shares[0], shares[1]})
+	require.NoError(t, err)
+	assert.Equal(t, bip39.ParseMnemonic(exampleMnemonic), mnemonic)
+}
+
+func TestSplit(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	for _, language := range []string{"english", "japanese"} {
+		codec := newCodec(t, language)
+		for _, size := range []int{16, 24, 32} {
+			entropy := make([]byte, size)
+			rng.Read(entropy)
+			mnemonic, err := codec.EntropyToMnemonic(entropy)
+			require.NoError(t, err)
+
+			for n := 2; n <= 4; n++ {
+				shares, err := seedxor.Split(rng, codec, mnemonic, n)
+				require.NoError(t, err)
+				require.Len(t, shares, n)
+				for _, share := range shares {
+					assert.Len(t, share, len(mnemonic))
+					// each share must be a valid mnemonic
+					_, err := codec.MnemonicToEntropy(share)
+					assert.NoError(t, err)
+				}
+
+				combined, err := seedxor.Combine(codec, shares)
+				require.NoError(t, err)
+				assert.Equal(t, mnemonic, combined)
+			}
+		}
+	}
+}
+
+func TestSplitInvalid(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	english := newCodec(t, "english")
+	mnemonic := bip39.ParseMnemonic(exampleMnemonic)
+
+	_, err := seedxor.Split(rng, english, mnemonic, 1)
+	assert.ErrorIs(t, err, seedxor.ErrInvalidShareCount)
+	_, err = seedxor.Split(rng, english, bip39.ParseMnemonic(strings.Repeat("abandon ", 14)+"able"), 2)
+	assert.ErrorIs(t, err, seedxor.ErrInvalidLength)
+	_, err = seedxor.Split(rng, english, mnemonic[1:], 2)
+	assert.ErrorIs(t, err, seedxor.ErrInvalidLength)
+	_, err = seedxor.Split(rng, english, append(bip39.Mnemonic{"toe"}, mnemonic[1:]...), 2)
+	assert.ErrorIs(t, err, bip39.ErrInvalidChecksum)
+	_, err = seedxor.Split(strings.NewReader(""), english, mnemonic, 2)
+	assert.Error(t, err)
+}
+
+func TestCombineInvalid(t *testing.T) {
+	english := newCodec(t, "english")
+	share := bip39.ParseMnemonic(exampleShares[0])
+	short := bip39.ParseMnemonic(strings.Repeat("abandon ", 11) + "about")
+
+	_, err := seedxor.Combine(english, []bip39.Mnemonic{share})
+	assert.ErrorIs(t, err, seedxor.ErrInvalidShareCount)
+	_, err = seedxor.Combine(english, []bip39.Mnemonic{share, short})
+	assert.ErrorIs(t, err, seedxor.ErrLengthMismatch)
+	_, err = seedxor.Combine(english, []bip39.Mnemonic{share, share[1:]})
+	assert.ErrorIs(t, err, seedxor.ErrInvalidLength)
+	_, err = seedxor.Combine(english, []bip39.Mnemonic{share, append(bip39.Mnemonic{"vault"}, share[1:]...)})
+	assert.ErrorIs(t, err, bip39.ErrInvalidChecksum)
+}
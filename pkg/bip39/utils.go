@@ -3,6 +3,8 @@ package bip39
 import (
 	"fmt"
 	"math/big"
+
+	"github.com/wollac/iota-crypto-demo/pkg/bip39/wordlist"
 )
 
 const (
@@ -32,14 +34,14 @@ func validateEntropy(entropy []byte) error {
 	return nil
 }
 
-func validateMnemonic(mnemonic Mnemonic) error {
+func validateMnemonic(list wordlist.List, mnemonic Mnemonic) error {
 	ms := len(mnemonic)
 	if ms%3 != 0 || entropyBitsToWordCount(entropyMinBits) > ms || ms > entropyBitsToWordCount(entropyMaxBits) {
 		return fmt.Errorf("%w: unsupported word count (%d)", ErrInvalidMnemonic, ms)
 	}
 
 	for _, word := range mnemonic {
-		if !wordList.Contains(word) {
+		if !list.Contains(word) {
 			return fmt.Errorf("%w: invalid word (%s)", ErrInvalidMnemonic, word)
 		}
 	}
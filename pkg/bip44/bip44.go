@@ -0,0 +1,215 @@
+/*
+Package bip44 implements the account discovery described in BIP-0044.
+
+The discovery derives the addresses of each account following the path
+
+	m / 44' / coin_type' / account' / change / address_index
+
+and queries an Oracle whether they have been used. The addresses of a chain are
+scanned until GapLimit consecutive addresses are unused. Accounts are scanned
+in order until an account without any used external address is found.
+
+The hardening of the change and address index levels can be configured. For
+Ed25519 they must be hardened and the resulting addresses are of the type
+address.Ed25519Address.
+*/
+package bip44
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/wollac/iota-crypto-demo/pkg/bech32/address"
+	"github.com/wollac/iota-crypto-demo/pkg/bip32path"
+	"github.com/wollac/iota-crypto-demo/pkg/ed25519"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/eddsa"
+)
+
+const (
+	// Purpose is the hardened index of the first derivation level as defined in BIP-0044.
+	Purpose = 44 | slip10.Hardened
+
+	// DefaultGapLimit is the number of consecutive unused addresses after which the scan of a chain stops.
+	DefaultGapLimit = 20
+)
+
+// Indices of the change level.
+const (
+	// ExternalChain is the chain of addresses that are meant to be visible outside of the wallet.
+	ExternalChain uint32 = 0
+	// InternalChain is the chain of addresses that are not meant to be visible outside of the wallet.
+	InternalChain uint32 = 1
+)
+
+// ErrUnsupportedKey is returned when no address can be generated for a key.
+var ErrUnsupportedKey = errors.New("unsupported key")
+
+// An Oracle reports whether an address has been used, e.g. by querying the ledger.
+type Oracle interface {
+	IsUsed(addr address.Address) (bool, error)
+}
+
+// An AddressFunc returns the address corresponding to an extended key.
+type AddressFunc func(key *slip10.ExtendedKey) (address.Address, error)
+
+// Discovery contains the parameters of an account discovery.
+type Discovery struct {
+	// Curve is the curve used to derive the keys.
+	Curve slip10.Curve
+	// CoinType is the registered coin type, without the hardened bit.
+	CoinType uint32
+	// Oracle reports whether the derived addresses have been used.
+	Oracle Oracle
+
+	// GapLimit is the number of consecutive unused addresses after which the scan of a chain stops.
+	GapLimit int
+	// HardenedChange reports whether the change level is derived hardened.
+	HardenedChange bool
+	// HardenedIndex reports whether the address index level is derived hardened.
+	HardenedIndex bool
+	// Address computes the address of a derived key.
+	Address AddressFunc
+}
+
+// Address is a used address found during the discovery.
+type Address struct {
+	// Path is the full derivation path of the address.
+	Path bip32path.Path
+	// Change is the index of the chain of the address, i.e. ExternalChain or InternalChain.
+	Change uint32
+	// Index is the address index, without the hardened bit.
+	Index uint32
+	// Address is the actual address.
+	Address address.Address
+}
+
+// Account is an account with at least one used address found during the discovery.
+type Account struct {
+	// Index is the account index, without the hardened bit.
+	Index uint32
+	// Addresses contains the used addresses of the account in the order of their derivation.
+	Addresses []Address
+}
+
+// New creates a new Discovery with the default gap limit.
+// For the Ed25519 curve all levels are hardened, as SLIP-10 only supports hardened derivation for Ed25519.
+// For Ed25519 and BIP32-Ed25519 curves, Ed25519Address is used to compute the addresses.
+// For all other curves, Address must be set before calling Discover.
+func New(curve slip10.Curve, coinType uint32, oracle Oracle) *Discovery {
+	d := &Discovery{
+		Curve:    curve,
+		CoinType: coinType,
+		Oracle:   oracle,
+		GapLimit: DefaultGapLimit,
+	}
+	switch curve.Name() {
+	case eddsa.Ed25519().Name():
+		d.HardenedChange = true
+		d.HardenedIndex = true
+		d.Address = Ed25519Address
+	case eddsa.BIP32Ed25519().Name(), eddsa.Cardano().Name():
+		d.Address = Ed25519Address
+	}
+	return d
+}
+
+// Discover scans the accounts derived from seed and returns all accounts with used addresses.
+func (d *Discovery) Discover(seed []byte) ([]Account, error) {
+	if d.Address == nil {
+		return nil, fmt.Errorf("%w: no address function for %s", ErrUnsupportedKey, d.Curve.Name())
+	}
+	gapLimit := d.GapLimit
+	if gapLimit <= 0 {
+		gapLimit = DefaultGapLimit
+	}
+
+	master, err := slip10.NewMasterKey(seed, d.Curve)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate master key: %w", err)
+	}
+	defer master.Wipe()
+	keychain := slip10.NewKeychain(master)
+	defer keychain.Wipe()
+
+	var accounts []Account
+	for account := uint32(0); account < slip10.Hardened; account++ {
+		accountPath := bip32path.Path{Purpose, d.CoinType | slip10.Hardened, account | slip10.Hardened}
+
+		external, err := d.scanChain(keychain, accountPath, ExternalChain, gapLimit)
+		if err != nil {
+			return nil, err
+		}
+		// stop the discovery, if there are no used addresses on the external chain
+		if len(external) == 0 {
+			break
+		}
+		internal, err := d.scanChain(keychain, accountPath, InternalChain, gapLimit)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, Account{
+			Index:     account,
+			Addresses: append(external, internal...),
+		})
+	}
+	return accounts, nil
+}
+
+// scanChain returns the used addresses of the given chain until gapLimit consecutive addresses are unused.
+func (d *Discovery) scanChain(keychain *slip10.Keychain, accountPath bip32path.Path, change uint32, gapLimit int) ([]Address, error) {
+	changeIndex := change
+	if d.HardenedChange {
+		changeIndex |= slip10.Hardened
+	}
+	chainPath := append(accountPath[:len(accountPath):len(accountPath)], changeIndex)
+
+	var used []Address
+	for index, gap := uint32(0), 0; gap < gapLimit && index < slip10.Hardened; index++ {
+		addressIndex := index
+		if d.HardenedIndex {
+			addressIndex |= slip10.Hardened
+		}
+		path := append(chainPath[:len(chainPath):len(chainPath)], addressIndex)
+
+		key, err := keychain.DeriveKey(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive %s: %w", path, err)
+		}
+		addr, err := d.Address(key)
+		key.Wipe()
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute address of %s: %w", path, err)
+		}
+		ok, err := d.Oracle.IsUsed(addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query address %s: %w", addr, err)
+		}
+		if !ok {
+			gap++
+			continue
+		}
+		gap = 0
+		used = append(used, Address{
+			Path:    path,
+			Change:  change,
+			Index:   index,
+			Address: addr,
+		})
+	}
+	return used, nil
+}
+
+// Ed25519Address is an AddressFunc that returns the address.Ed25519Address of Ed25519 and BIP32-Ed25519 keys.
+func Ed25519Address(key *slip10.ExtendedKey) (address.Address, error) {
+	var publicKey ed25519.PublicKey
+	switch k := key.Key.Public().(type) {
+	case eddsa.PublicKey:
+		publicKey = ed25519.PublicKey(k)
+	case eddsa.ExtendedPublicKey:
+		publicKey = k.Ed25519PublicKey()
+	default:
+		return nil, fmt.Errorf("%w: %T is not an Ed25519 key", ErrUnsupportedKey, key.Key)
+	}
+	return address.AddressFromPublicKey(publicKey), nil
+}
@@ -0,0 +1,113 @@
+package bip44_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wollac/iota-crypto-demo/pkg/bech32/address"
+	"github.com/wollac/iota-crypto-demo/pkg/bip32path"
+	"github.com/wollac/iota-crypto-demo/pkg/bip44"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/eddsa"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+const iotaCoinType = 4218
+
+var seed, _ = hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+// oracle reports all addresses in the set as used.
+type oracle map[string]bool
+
+func (o oracle) IsUsed(addr address.Address) (bool, error) {
+	return o[addr.String()], nil
+}
+
+type errOracle struct{ err error }
+
+func (o errOracle) IsUsed(address.Address) (bool, error) {
+	return false, o.err
+}
+
+func mustAddress(t *testing.T, curve slip10.Curve, s string) address.Address {
+	path, err := bip32path.ParsePath(s)
+	require.NoError(t, err)
+	key, err := slip10.DeriveKeyFromPath(seed, curve, path)
+	require.NoError(t, err)
+	addr, err := bip44.Ed25519Address(key)
+	require.NoError(t, err)
+	return addr
+}
+
+func TestDiscover(t *testing.T) {
+	used := []string{
+		"m/44'/4218'/0'/0'/0'",
+		"m/44'/4218'/0'/0'/5'",
+		"m/44'/4218'/0'/0'/25'",
+		"m/44'/4218'/0'/1'/3'",
+		"m/44'/4218'/1'/0'/19'",
+		// account 2 is unused, so account 3 must not be discovered
+		"m/44'/4218'/3'/0'/0'",
+	}
+	o := oracle{}
+	for _, s := range used {
+		o[mustAddress(t, eddsa.Ed25519(), s).String()] = true
+	}
+
+	accounts, err := bip44.New(eddsa.Ed25519(), iotaCoinType, o).Discover(seed)
+	require.NoError(t, err)
+
+	var paths [][]string
+	for i, account := range accounts {
+		assert.EqualValues(t, i, account.Index)
+		var p []string
+		for _, addr := range account.Addresses {
+			assert.IsType(t, address.Ed25519Address{}, addr.Address)
+			assert.Equal(t, mustAddress(t, eddsa.Ed25519(), addr.Path.String()), addr.Address)
+			p = append(p, addr.Path.String())
+		}
+		paths = append(paths, p)
+	}
+	assert.Equal(t, [][]string{used[:4], used[4:5]}, paths)
+}
+
+func TestDiscoverGapLimit(t *testing.T) {
+	o := oracle{
+		mustAddress(t, eddsa.Ed25519(), "m/44'/4218'/0'/0'/0'").String():  true,
+		mustAddress(t, eddsa.Ed25519(), "m/44'/4218'/0'/0'/10'").String(): true,
+	}
+
+	d := bip44.New(eddsa.Ed25519(), iotaCoinType, o)
+	d.GapLimit = 5
+	accounts, err := d.Discover(seed)
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Len(t, accounts[0].Addresses, 1)
+	assert.EqualValues(t, 0, accounts[0].Addresses[0].Index)
+	assert.Equal(t, bip44.ExternalChain, accounts[0].Addresses[0].Change)
+}
+
+func TestDiscoverNonHardened(t *testing.T) {
+	// BIP32-Ed25519 supports non-hardened derivation of the change and index levels
+	o := oracle{
+		mustAddress(t, eddsa.BIP32Ed25519(), "m/44'/4218'/0'/0/7").String(): true,
+	}
+
+	accounts, err := bip44.New(eddsa.BIP32Ed25519(), iotaCoinType, o).Discover(seed)
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	require.Len(t, accounts[0].Addresses, 1)
+	assert.Equal(t, "m/44'/4218'/0'/0/7", accounts[0].Addresses[0].Path.String())
+}
+
+func TestDiscoverErrors(t *testing.T) {
+	_, err := bip44.New(elliptic.Secp256k1(), 0, oracle{}).Discover(seed)
+	assert.ErrorIs(t, err, bip44.ErrUnsupportedKey)
+
+	errTest := errors.New("test")
+	_, err = bip44.New(eddsa.Ed25519(), iotaCoinType, errOracle{errTest}).Discover(seed)
+	assert.ErrorIs(t, err, errTest)
+}
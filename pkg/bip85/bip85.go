@@ -0,0 +1,231 @@
+/*
+Package bip85 implements the BIP-0085 deterministic entropy derivation from a
+BIP-0032 root key.
+
+It allows a single backed-up root key to derive independent secrets for other
+wallets or applications. The following applications are supported:
+
+	BIP39:      child mnemonics with 12, 18 or 24 words
+	WIF:        private keys in the Wallet Import Format
+	HEX:        raw entropy of 16 to 64 bytes
+	PWD BASE64: passwords of 20 to 86 characters
+
+The derivation uses the secp256k1 curve as outlined by SLIP-0010 which is
+compatible with BIP-0032.
+
+This package is tested against the test vectors provided in the official
+BIP-0085 specification.
+*/
+package bip85
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/wollac/iota-crypto-demo/internal/wipe"
+	"github.com/wollac/iota-crypto-demo/pkg/base58"
+	"github.com/wollac/iota-crypto-demo/pkg/bip32path"
+	"github.com/wollac/iota-crypto-demo/pkg/bip39"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+const (
+	// EntropySize is the size, in bytes, of the entropy derived for each path.
+	EntropySize = 64
+
+	// Purpose is the hardened index of the first derivation level as defined in BIP-0085.
+	Purpose = 83696968 | slip10.Hardened
+)
+
+// Application numbers as defined in BIP-0085.
+const (
+	appBIP39     = 39
+	appWIF       = 2
+	appHex       = 128169
+	appPwdBase64 = 707764
+)
+
+// Language identifies a BIP-0039 word list language in the BIP39 application.
+type Language uint32
+
+// Languages as defined in BIP-0085.
+const (
+	English Language = iota
+	Japanese
+	Korean
+	Spanish
+	ChineseSimplified
+	ChineseTraditional
+	French
+	Italian
+	Czech
+	Portuguese
+)
+
+// names of the corresponding word lists registered in bip39
+var languageNames = map[Language]string{
+	English:            "english",
+	Japanese:           "japanese",
+	Korean:             "korean",
+	Spanish:            "spanish",
+	ChineseSimplified:  "chinese_simplified",
+	ChineseTraditional: "chinese_traditional",
+	French:             "french",
+	Italian:            "italian",
+	Czech:              "czech",
+	Portuguese:         "portuguese",
+}
+
+var (
+	// ErrInvalidKey is returned when the root key is not a secp256k1 extended private key.
+	ErrInvalidKey = errors.New("invalid root key")
+	// ErrInvalidWordCount is returned when the number of words is not supported by the BIP39 application.
+	ErrInvalidWordCount = errors.New("invalid word count")
+	// ErrInvalidLength is returned when the requested length is not supported by the application.
+	ErrInvalidLength = errors.New("invalid length")
+	// ErrUnsupportedLanguage is returned when no BIP-0039 word list is registered for the language.
+	ErrUnsupportedLanguage = errors.New("unsupported language")
+)
+
+// wifVersion is the version byte of mainnet private keys in the Wallet Import Format.
+const wifVersion = 0x80
+
+var entropyHmacKey = []byte("bip-entropy-from-k")
+
+// DeriveEntropy derives the 64-byte entropy for path from the root key.
+// The path must contain all levels starting with Purpose.
+func DeriveEntropy(root *slip10.ExtendedKey, path bip32path.Path) ([]byte, error) {
+	if !isSecp256k1PrivateKey(root.Key) {
+		return nil, ErrInvalidKey
+	}
+	key := root
+	for _, index := range path {
+		child, err := key.DeriveChild(index)
+		// the intermediate keys are only needed for the derivation
+		if key != root {
+			key.Wipe()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive child key: %w", err)
+		}
+		key = child
+	}
+	entropy := entropyFromKey(key)
+	if key != root {
+		key.Wipe()
+	}
+	return entropy, nil
+}
+
+// DeriveEntropyFromSeed derives the 64-byte entropy for path from the secp256k1 master key of seed.
+// The path must contain all levels starting with Purpose.
+func DeriveEntropyFromSeed(seed []byte, path bip32path.Path) ([]byte, error) {
+	key, err := slip10.DeriveKeyFromPath(seed, elliptic.Secp256k1(), path)
+	if err != nil {
+		return nil, err
+	}
+	defer key.Wipe()
+	return entropyFromKey(key), nil
+}
+
+// Mnemonic derives a BIP-0039 mnemonic with the given number of words using the BIP39 application.
+// The words are taken from the word list of the given language, which must be registered in bip39.
+func Mnemonic(root *slip10.ExtendedKey, language Language, words int, index uint32) (bip39.Mnemonic, error) {
+	if words != 12 && words != 18 && words != 24 {
+		return nil, fmt.Errorf("%w: %d words are not supported", ErrInvalidWordCount, words)
+	}
+	codec, err := newCodec(language)
+	if err != nil {
+		return nil, err
+	}
+	entropy, err := DeriveEntropy(root, hardenedPath(appBIP39, uint32(language), uint32(words), index))
+	if err != nil {
+		return nil, err
+	}
+	// truncate the entropy to the number of bits matching the word count
+	return codec.EntropyToMnemonic(entropy[:words*4/3])
+}
+
+// WIF derives a secp256k1 private key using the HD-Seed WIF application.
+// The key is returned in the compressed Wallet Import Format.
+func WIF(root *slip10.ExtendedKey, index uint32) (string, error) {
+	entropy, err := DeriveEntropy(root, hardenedPath(appWIF, index))
+	if err != nil {
+		return "", err
+	}
+	buf := make([]byte, 0, 1+slip10.PrivateKeySize+1)
+	buf = append(buf, wifVersion)
+	buf = append(buf, entropy[:slip10.PrivateKeySize]...)
+	buf = append(buf, 0x01) // compressed public key
+	return base58.CheckEncode(buf), nil
+}
+
+// Hex derives numBytes bytes of entropy using the HEX application and returns them hex encoded.
+// The number of bytes must be between 16 and 64.
+func Hex(root *slip10.ExtendedKey, numBytes int, index uint32) (string, error) {
+	if numBytes < 16 || numBytes > EntropySize {
+		return "", fmt.Errorf("%w: %d bytes are not supported", ErrInvalidLength, numBytes)
+	}
+	entropy, err := DeriveEntropy(root, hardenedPath(appHex, uint32(numBytes), index))
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(entropy[:numBytes]), nil
+}
+
+// PasswordBase64 derives a password with the given length using the PWD BASE64 application.
+// The length must be between 20 and 86 characters.
+func PasswordBase64(root *slip10.ExtendedKey, length int, index uint32) (string, error) {
+	if length < 20 || length > 86 {
+		return "", fmt.Errorf("%w: password length %d is not supported", ErrInvalidLength, length)
+	}
+	entropy, err := DeriveEntropy(root, hardenedPath(appPwdBase64, uint32(length), index))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(entropy)[:length], nil
+}
+
+// entropyFromKey computes HMAC-SHA512(Key = "bip-entropy-from-k", Data = k) for the private key k.
+func entropyFromKey(key *slip10.ExtendedKey) []byte {
+	// the serialization of elliptic keys is a copy that must be wiped
+	k := key.Key.Bytes()
+	defer wipe.Bytes(k)
+
+	h := hmac.New(sha512.New, entropyHmacKey)
+	h.Write(k)
+	return h.Sum(nil)
+}
+
+// hardenedPath returns the path starting with Purpose followed by the hardened indices.
+func hardenedPath(indices ...uint32) bip32path.Path {
+	path := make(bip32path.Path, 0, 1+len(indices))
+	path = append(path, Purpose)
+	for _, i := range indices {
+		path = append(path, i|slip10.Hardened)
+	}
+	return path
+}
+
+// newCodec returns a bip39.Codec using the word list of the given language.
+func newCodec(language Language) (*bip39.Codec, error) {
+	name, ok := languageNames[language]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedLanguage, uint32(language))
+	}
+	codec, err := bip39.NewCodecForLanguage(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedLanguage, name)
+	}
+	return codec, nil
+}
+
+func isSecp256k1PrivateKey(key slip10.Key) bool {
+	k, ok := key.(*elliptic.PrivateKey)
+	return ok && k.Curve.Params().Name == elliptic.Secp256k1().Name()
+}
@@ -0,0 +1,152 @@
+package bip85_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wollac/iota-crypto-demo/pkg/bip32path"
+	"github.com/wollac/iota-crypto-demo/pkg/bip39"
+	"github.com/wollac/iota-crypto-demo/pkg/bip85"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/eddsa"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/elliptic"
+	"golang.org/x/text/unicode/norm"
+)
+
+// master key of all BIP-0085 test vectors
+const rootKey = "xprv9s21ZrQH143K2LBWUUQRFXhucrQqBpKdRRxNVq2zBqsx8HVqFk2uYo8kmbaLLHRdqtQpUm98uKfu3vca1LqdGhUtyoFnCNkfmXRyPXLjbKb"
+
+func parseRootKey(t *testing.T) *slip10.ExtendedKey {
+	key, err := slip10.ParseExtendedKey(rootKey, elliptic.Secp256k1())
+	require.NoError(t, err)
+	return key
+}
+
+func TestDeriveEntropy(t *testing.T) {
+	var tests = []*struct {
+		path    string
+		entropy string
+	}{
+		{
+			"m/83696968'/0'/0'",
+			"efecfbccffea313214232d29e71563d941229afb4338c21f9517c41aaa0d16f00b83d2a09ef747e7a64e8e2bd5a14869e693da66ce94ac2da570ab7ee48618f7",
+		},
+		{
+			"m/83696968'/0'/1'",
+			"70c6e3e8ebee8dc4c0dbba66076819bb8c09672527c4277ca8729532ad711872218f826919f6b67218adde99018a6df9095ab2b58d803b5b93ec9802085a690e",
+		},
+	}
+	root := parseRootKey(t)
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			path, err := bip32path.ParsePath(tt.path)
+			require.NoError(t, err)
+			entropy, err := bip85.DeriveEntropy(root, path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.entropy, hex.EncodeToString(entropy))
+		})
+	}
+}
+
+func TestDeriveEntropyFromSeed(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	root, err := slip10.NewMasterKey(seed, elliptic.Secp256k1())
+	require.NoError(t, err)
+
+	path := bip32path.Path{bip85.Purpose, 0 | slip10.Hardened, 0 | slip10.Hardened}
+	expected, err := bip85.DeriveEntropy(root, path)
+	require.NoError(t, err)
+	entropy, err := bip85.DeriveEntropyFromSeed(seed, path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, entropy)
+}
+
+func TestMnemonic(t *testing.T) {
+	var tests = []*struct {
+		words    int
+		mnemonic string
+	}{
+		{12, "girl mad pet galaxy egg matter matrix prison refuse sense ordinary nose"},
+		{18, "near account window bike charge season chef number sketch tomorrow excuse sniff circle vital hockey outdoor supply token"},
+		{24, "puppy ocean match cereal symbol another shed magic wrap hammer bulb intact gadget divorce twin tonight reason outdoor destroy simple truth cigar social volcano"},
+	}
+	root := parseRootKey(t)
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			mnemonic, err := bip85.Mnemonic(root, bip85.English, tt.words, 0)
+			require.NoError(t, err)
+			assert.Equal(t, bip39.ParseMnemonic(tt.mnemonic), mnemonic)
+		})
+	}
+
+	_, err := bip85.Mnemonic(root, bip85.English, 15, 0)
+	assert.ErrorIs(t, err, bip85.ErrInvalidWordCount)
+}
+
+func TestMnemonicLanguage(t *testing.T) {
+	// the BIP-0085 specification only contains English test vectors, the following mnemonics
+	// have been computed using the published test vector root key and the official BIP-0039 word lists
+	var tests = []*struct {
+		language bip85.Language
+		words    int
+		mnemonic string
+	}{
+		{bip85.Japanese, 12, "おまいり にんてい こふん ぎんいろ にんい ぜんご ひめい まほう たたみ さとう ざいたく あてな"},
+		{bip85.Spanish, 18, "ruido edificio pétalo pie asunto furia bueno peine vivero gesto viaje osadía nación copa paleta libertad dirigir tortuga"},
+	}
+	root := parseRootKey(t)
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			mnemonic, err := bip85.Mnemonic(root, tt.language, tt.words, 0)
+			require.NoError(t, err)
+			assert.Equal(t, norm.NFKD.String(tt.mnemonic), norm.NFKD.String(mnemonic.String()))
+		})
+	}
+
+	// all languages of BIP-0085 must have a registered word list
+	for language := bip85.English; language <= bip85.Portuguese; language++ {
+		_, err := bip85.Mnemonic(root, language, 12, 0)
+		assert.NoError(t, err)
+	}
+
+	_, err := bip85.Mnemonic(root, bip85.Language(42), 12, 0)
+	assert.ErrorIs(t, err, bip85.ErrUnsupportedLanguage)
+}
+
+func TestWIF(t *testing.T) {
+	wif, err := bip85.WIF(parseRootKey(t), 0)
+	require.NoError(t, err)
+	assert.Equal(t, "Kzyv4uF39d4Jrw2W7UryTHwZr1zQVNk4dAFyqE6BuMrMh1Za7uhp", wif)
+}
+
+func TestHex(t *testing.T) {
+	s, err := bip85.Hex(parseRootKey(t), 64, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "492db4698cf3b73a5a24998aa3e9d7fa96275d85724a91e71aa2d645442f878555d078fd1f1f67e368976f04137b1f7a0d19232136ca50c44614af72b5582a5c", s)
+
+	_, err = bip85.Hex(parseRootKey(t), 15, 0)
+	assert.ErrorIs(t, err, bip85.ErrInvalidLength)
+}
+
+func TestPasswordBase64(t *testing.T) {
+	pwd, err := bip85.PasswordBase64(parseRootKey(t), 21, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "dKLoepugzdVJvdL56ogNV", pwd)
+
+	_, err = bip85.PasswordBase64(parseRootKey(t), 87, 0)
+	assert.ErrorIs(t, err, bip85.ErrInvalidLength)
+}
+
+func TestInvalidRootKey(t *testing.T) {
+	root := parseRootKey(t)
+	_, err := bip85.DeriveEntropy(root.Public(), bip32path.Path{bip85.Purpose})
+	assert.ErrorIs(t, err, bip85.ErrInvalidKey)
+
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	ed25519Root, err := slip10.NewMasterKey(seed, eddsa.Ed25519())
+	require.NoError(t, err)
+	_, err = bip85.DeriveEntropy(ed25519Root, bip32path.Path{bip85.Purpose})
+	assert.ErrorIs(t, err, bip85.ErrInvalidKey)
+}
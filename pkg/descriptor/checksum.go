@@ -0,0 +1,97 @@
+package descriptor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChecksumSize is the number of characters of a descriptor checksum.
+const ChecksumSize = 8
+
+const (
+	// inputCharset contains all characters allowed in descriptors, ordered to optimize the checksum.
+	inputCharset = "0123456789()[],'/*abcdefgh@:$%{}" +
+		"IJKLMNOPQRSTUVWXYZ&+-.;<=>?!^_|~" +
+		"ijklmnopqrstuvwxyzABCDEFGH`#\"\\ "
+	// checksumCharset is the character set used to encode the checksum; it matches Bech32.
+	checksumCharset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+)
+
+var generator = [5]uint64{0xf5dee51989, 0xa9fdca3312, 0x1bab10e32d, 0x3706b1677a, 0x644d626ffd}
+
+func polymod(c uint64, val int) uint64 {
+	c0 := c >> 35
+	c = (c&0x7ffffffff)<<5 ^ uint64(val)
+	for i := 0; i < 5; i++ {
+		if (c0>>i)&1 != 0 {
+			c ^= generator[i]
+		}
+	}
+	return c
+}
+
+// Checksum computes the checksum of the descriptor s as defined in BIP-380.
+// The descriptor must not contain a checksum.
+func Checksum(s string) (string, error) {
+	c := uint64(1)
+	cls, clsCount := 0, 0
+	for i, ch := range s {
+		pos := strings.IndexRune(inputCharset, ch)
+		if pos < 0 {
+			return "", fmt.Errorf("%w: invalid character %q at position %d", ErrInvalidSyntax, ch, i)
+		}
+		// emit a symbol for the position inside the group, for every character
+		c = polymod(c, pos&31)
+		// accumulate the group numbers
+		cls = cls*3 + pos>>5
+		if clsCount++; clsCount == 3 {
+			// emit an extra symbol representing the group numbers, for every 3 characters
+			c = polymod(c, cls)
+			cls, clsCount = 0, 0
+		}
+	}
+	if clsCount > 0 {
+		c = polymod(c, cls)
+	}
+	// shift further to determine the checksum
+	for i := 0; i < ChecksumSize; i++ {
+		c = polymod(c, 0)
+	}
+	// prevent appending zeroes from not affecting the checksum
+	c ^= 1
+
+	var b strings.Builder
+	for i := 0; i < ChecksumSize; i++ {
+		b.WriteByte(checksumCharset[(c>>(5*(7-i)))&31])
+	}
+	return b.String(), nil
+}
+
+// AddChecksum returns s followed by '#' and its checksum.
+func AddChecksum(s string) (string, error) {
+	checksum, err := Checksum(s)
+	if err != nil {
+		return "", err
+	}
+	return s + "#" + checksum, nil
+}
+
+// verifyChecksum removes the optional checksum from s and verifies it.
+func verifyChecksum(s string) (string, error) {
+	i := strings.LastIndexByte(s, '#')
+	if i < 0 {
+		return s, nil
+	}
+	desc, checksum := s[:i], s[i+1:]
+	if len(checksum) != ChecksumSize {
+		return "", fmt.Errorf("%w: expected %d characters", ErrInvalidChecksum, ChecksumSize)
+	}
+	expected, err := Checksum(desc)
+	if err != nil {
+		return "", err
+	}
+	if checksum != expected {
+		return "", fmt.Errorf("%w: expected %s", ErrInvalidChecksum, expected)
+	}
+	return desc, nil
+}
@@ -0,0 +1,171 @@
+/*
+Package descriptor implements output script descriptors with a single key
+expression as defined in BIP-0380.
+
+Key expressions consist of an optional key origin, an extended key, a
+derivation path and an optional wildcard:
+
+	[d34db33f/44'/0'/0']xpub6ERApfZwUNrhLCkDtcHTcxd75RbzS1ed54G1LkBUHQVHQKqhMkhgbmJbZRkrgZw4koxb5JaHWkY4ALHY2grBGRjaDMzQLcgJvLJuZZvRcEL/1/*
+
+Hardened derivation steps and wildcards can be denoted using ' or h. A
+descriptor wraps the key expression in script functions such as pkh(KEY),
+wpkh(KEY) or sh(wpkh(KEY)) and can be followed by a checksum.
+
+Expanding a descriptor derives the described extended public keys. As the key
+expressions are based on BIP-0032 extended keys, only the curves of the
+slip10/elliptic package are supported.
+*/
+package descriptor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wollac/iota-crypto-demo/pkg/slip10"
+)
+
+// Errors returned when parsing or expanding descriptors.
+var (
+	ErrInvalidSyntax     = errors.New("invalid syntax")
+	ErrInvalidChecksum   = errors.New("invalid checksum")
+	ErrInvalidKey        = errors.New("invalid key")
+	ErrUnsupportedScript = errors.New("unsupported script")
+)
+
+// scriptRule specifies where a script function may be used.
+type scriptRule struct {
+	key      bool     // whether the function takes a key expression as argument
+	topLevel bool     // whether the function can be used at the top level
+	parents  []string // other functions in which the function can be used
+}
+
+var scripts = map[string]scriptRule{
+	"pk":    {key: true, topLevel: true, parents: []string{"sh", "wsh"}},
+	"pkh":   {key: true, topLevel: true, parents: []string{"sh", "wsh"}},
+	"wpkh":  {key: true, topLevel: true, parents: []string{"sh"}},
+	"combo": {key: true, topLevel: true},
+	"tr":    {key: true, topLevel: true},
+	"sh":    {topLevel: true},
+	"wsh":   {topLevel: true, parents: []string{"sh"}},
+}
+
+// Descriptor is an output script descriptor with a single key expression.
+type Descriptor struct {
+	// Scripts contains the names of the script functions starting with the outermost, e.g. ["sh", "wpkh"].
+	Scripts []string
+	// Key is the key expression in the innermost script function.
+	Key *KeyExpression
+}
+
+// Parse parses s as a descriptor with extended keys for the given curve.
+// If s contains a checksum, it must be valid.
+func Parse(s string, curve slip10.Curve) (*Descriptor, error) {
+	desc, err := verifyChecksum(s)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Descriptor{}
+	for {
+		open := strings.IndexByte(desc, '(')
+		if open < 0 {
+			break
+		}
+		if !strings.HasSuffix(desc, ")") {
+			return nil, fmt.Errorf("%w: missing closing parenthesis", ErrInvalidSyntax)
+		}
+		d.Scripts = append(d.Scripts, desc[:open])
+		desc = desc[open+1 : len(desc)-1]
+
+		// the key origin must not be mistaken for the script
+		if strings.HasPrefix(desc, "[") {
+			break
+		}
+	}
+	if err := validateScripts(d.Scripts); err != nil {
+		return nil, err
+	}
+
+	d.Key, err = ParseKeyExpression(desc, curve)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// IsRange reports whether the descriptor describes a range of keys.
+func (d *Descriptor) IsRange() bool {
+	return d.Key.IsRange()
+}
+
+// Expand derives the extended public keys for the indices from (inclusive) to to (exclusive).
+// If the descriptor is not ranged, the single described key is returned.
+func (d *Descriptor) Expand(from, to uint32) ([]*slip10.ExtendedKey, error) {
+	if !d.IsRange() {
+		key, err := d.Key.Derive(0)
+		if err != nil {
+			return nil, err
+		}
+		return []*slip10.ExtendedKey{key}, nil
+	}
+
+	var keys []*slip10.ExtendedKey
+	for i := from; i < to; i++ {
+		key, err := d.Key.Derive(i)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// String returns the descriptor including its checksum.
+func (d *Descriptor) String() string {
+	var b strings.Builder
+	for _, script := range d.Scripts {
+		b.WriteString(script)
+		b.WriteByte('(')
+	}
+	b.WriteString(d.Key.String())
+	b.WriteString(strings.Repeat(")", len(d.Scripts)))
+
+	s, err := AddChecksum(b.String())
+	if err != nil {
+		return "%!(" + err.Error() + ")"
+	}
+	return s
+}
+
+func validateScripts(names []string) error {
+	if len(names) == 0 {
+		return fmt.Errorf("%w: missing script function", ErrInvalidSyntax)
+	}
+	for i, name := range names {
+		rule, ok := scripts[name]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnsupportedScript, name)
+		}
+		if i == 0 && !rule.topLevel {
+			return fmt.Errorf("%w: %s cannot be used at the top level", ErrUnsupportedScript, name)
+		}
+		if i > 0 && !contains(rule.parents, names[i-1]) {
+			return fmt.Errorf("%w: %s cannot be used inside %s", ErrUnsupportedScript, name, names[i-1])
+		}
+		// only the innermost function takes the key expression
+		if rule.key != (i == len(names)-1) {
+			return fmt.Errorf("%w: invalid arguments for %s", ErrUnsupportedScript, name)
+		}
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+package descriptor_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wollac/iota-crypto-demo/pkg/bip32path"
+	"github.com/wollac/iota-crypto-demo/pkg/descriptor"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+// keys of the BIP-32 test vector 1
+const (
+	tv1Seed   = "000102030405060708090a0b0c0d0e0f"
+	tv1Master = "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi"
+	tv1Xprv0H = "xprv9uHRZZhk6KAJC1avXpDAp4MDc3sQKNxDiPvvkX8Br5ngLNv1TxvUxt4cV1rGL5hj6KCesnDYUhd7oWgT11eZG7XnxHrnYeSvkzY7d2bhkJ7"
+	tv1Xpub0H = "xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw"
+	// m/0H/1/2H
+	tv1Xpub0H12H = "xpub6D4BDPcP2GT577Vvch3R8wDkScZWzQzMMUm3PWbmWvVJrZwQY4VUNgqFJPMM3No2dFDFGTsxxpG5uJh7n7epu4trkrX7x7DogT5Uv6fcLW5"
+)
+
+func TestChecksum(t *testing.T) {
+	var tests = []*struct {
+		desc     string
+		checksum string
+	}{
+		{"raw(deadbeef)", "89f8spxm"},
+		{"pkh([d34db33f/44'/0'/0']xpub6ERApfZwUNrhLCkDtcHTcxd75RbzS1ed54G1LkBUHQVHQKqhMkhgbmJbZRkrgZw4koxb5JaHWkY4ALHY2grBGRjaDMzQLcgJvLJuZZvRcEL/1/*)", "ml40v0wf"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			checksum, err := descriptor.Checksum(tt.desc)
+			require.NoError(t, err)
+			assert.Equal(t, tt.checksum, checksum)
+		})
+	}
+
+	_, err := descriptor.Checksum("raw(deadbeef)\n")
+	assert.ErrorIs(t, err, descriptor.ErrInvalidSyntax)
+}
+
+func TestParse(t *testing.T) {
+	// out is the expected descriptor without checksum
+	var tests = []*struct {
+		in  string
+		out string
+	}{
+		{
+			"pkh([d34db33f/44'/0'/0']xpub6ERApfZwUNrhLCkDtcHTcxd75RbzS1ed54G1LkBUHQVHQKqhMkhgbmJbZRkrgZw4koxb5JaHWkY4ALHY2grBGRjaDMzQLcgJvLJuZZvRcEL/1/*)#ml40v0wf",
+			"pkh([d34db33f/44'/0'/0']xpub6ERApfZwUNrhLCkDtcHTcxd75RbzS1ed54G1LkBUHQVHQKqhMkhgbmJbZRkrgZw4koxb5JaHWkY4ALHY2grBGRjaDMzQLcgJvLJuZZvRcEL/1/*)",
+		},
+		{
+			"sh(wpkh([3442193e/0h]" + tv1Xpub0H + "/1/*))",
+			"sh(wpkh([3442193e/0']" + tv1Xpub0H + "/1/*))",
+		},
+		{
+			"wpkh(" + tv1Xprv0H + "/1/*h)",
+			"wpkh(" + tv1Xprv0H + "/1/*')",
+		},
+		{
+			"pk(" + tv1Master + ")",
+			"pk(" + tv1Master + ")",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			d, err := descriptor.Parse(tt.in, elliptic.Secp256k1())
+			require.NoError(t, err)
+
+			expected, err := descriptor.AddChecksum(tt.out)
+			require.NoError(t, err)
+			assert.Equal(t, expected, d.String())
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	var tests = []*struct {
+		in  string
+		err error
+	}{
+		{"pkh(" + tv1Xpub0H + ")#ml40v0wf", descriptor.ErrInvalidChecksum},
+		{"pkh(" + tv1Xpub0H + ")#ml40", descriptor.ErrInvalidChecksum},
+		{"pkh(" + tv1Xpub0H, descriptor.ErrInvalidSyntax},
+		{tv1Xpub0H, descriptor.ErrInvalidSyntax},
+		{"foo(" + tv1Xpub0H + ")", descriptor.ErrUnsupportedScript},
+		{"wpkh(wpkh(" + tv1Xpub0H + "))", descriptor.ErrUnsupportedScript},
+		{"sh(" + tv1Xpub0H + ")", descriptor.ErrUnsupportedScript},
+		{"sh(tr(" + tv1Xpub0H + "))", descriptor.ErrUnsupportedScript},
+		{"pkh([3442193/0']" + tv1Xpub0H + ")", descriptor.ErrInvalidSyntax},
+		{"pkh([3442193e/0'" + tv1Xpub0H + ")", descriptor.ErrInvalidSyntax},
+		{"pkh([3442193e/x]" + tv1Xpub0H + ")", descriptor.ErrInvalidSyntax},
+		{"pkh(" + tv1Xpub0H + "/1/m)", descriptor.ErrInvalidSyntax},
+		{"pkh(" + tv1Xpub0H + "/*/1)", descriptor.ErrInvalidSyntax},
+		{"pkh(" + tv1Xpub0H + "/)", descriptor.ErrInvalidSyntax},
+		{"pkh(" + tv1Xpub0H + "/1//2)", descriptor.ErrInvalidSyntax},
+		{"pkh([d34db33f/]" + tv1Xpub0H + ")", descriptor.ErrInvalidSyntax},
+		{"pkh(xpub)", descriptor.ErrInvalidKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			_, err := descriptor.Parse(tt.in, elliptic.Secp256k1())
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestNewKeyExpression(t *testing.T) {
+	master, err := slip10.ParseExtendedKey(tv1Master, elliptic.Secp256k1())
+	require.NoError(t, err)
+
+	k, err := descriptor.NewKeyExpression(master, bip32path.Path{0 | slip10.Hardened})
+	require.NoError(t, err)
+	assert.Equal(t, "[3442193e/0']"+tv1Xpub0H, k.String())
+}
+
+func TestExpand(t *testing.T) {
+	seed, _ := hex.DecodeString(tv1Seed)
+
+	d, err := descriptor.Parse("pkh([3442193e/0']"+tv1Xpub0H+"/1/*)", elliptic.Secp256k1())
+	require.NoError(t, err)
+	require.True(t, d.IsRange())
+
+	keys, err := d.Expand(5, 10)
+	require.NoError(t, err)
+	require.Len(t, keys, 5)
+	for i, key := range keys {
+		path := bip32path.Path{0 | slip10.Hardened, 1, uint32(5 + i)}
+		expected, err := slip10.DeriveKeyFromPath(seed, elliptic.Secp256k1(), path)
+		require.NoError(t, err)
+		assert.Equal(t, expected.Public().String(), key.String())
+		assert.False(t, key.IsPrivate())
+		// the origin of the key expression results in the full path
+		assert.Equal(t, path, key.Path())
+	}
+}
+
+func TestExpandHardened(t *testing.T) {
+	d, err := descriptor.Parse("pkh([3442193e/0']"+tv1Xprv0H+"/1/*')", elliptic.Secp256k1())
+	require.NoError(t, err)
+	keys, err := d.Expand(2, 3)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, tv1Xpub0H12H, keys[0].String())
+
+	// hardened derivation requires a private key
+	d, err = descriptor.Parse("pkh([3442193e/0']"+tv1Xpub0H+"/1/*')", elliptic.Secp256k1())
+	require.NoError(t, err)
+	_, err = d.Expand(2, 3)
+	assert.ErrorIs(t, err, slip10.ErrHardenedChildPublicKey)
+}
+
+func TestExpandSingle(t *testing.T) {
+	d, err := descriptor.Parse("wpkh("+tv1Xprv0H+")", elliptic.Secp256k1())
+	require.NoError(t, err)
+	require.False(t, d.IsRange())
+
+	keys, err := d.Expand(0, 10)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, tv1Xpub0H, keys[0].String())
+}
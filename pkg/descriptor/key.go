@@ -0,0 +1,241 @@
+package descriptor
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/wollac/iota-crypto-demo/pkg/base58"
+	"github.com/wollac/iota-crypto-demo/pkg/bip32path"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10"
+)
+
+// Wildcard denotes whether and how a key expression is ranged.
+type Wildcard int
+
+// Wildcard types of key expressions.
+const (
+	// NoWildcard denotes a key expression that describes exactly one key.
+	NoWildcard Wildcard = iota
+	// UnhardenedWildcard denotes a key expression ending with "/*".
+	UnhardenedWildcard
+	// HardenedWildcard denotes a key expression ending with "/*'".
+	HardenedWildcard
+)
+
+// KeyOrigin describes the origin of a key, i.e. the fingerprint of the master key and the path to derive it.
+type KeyOrigin struct {
+	// Fingerprint is the fingerprint of the master key.
+	Fingerprint []byte
+	// Path is the derivation path from the master key to the key.
+	Path bip32path.Path
+}
+
+// String returns the key origin in the form "d34db33f/44'/0'/0'" without the enclosing brackets.
+func (o *KeyOrigin) String() string {
+	return hex.EncodeToString(o.Fingerprint) + pathSuffix(o.Path)
+}
+
+// KeyExpression is a key expression based on an extended key as defined in BIP-380.
+type KeyExpression struct {
+	// Origin is the optional key origin information.
+	Origin *KeyOrigin
+	// Key is the extended private or public key.
+	Key *slip10.ExtendedKey
+	// Versions are the versions used to serialize Key.
+	Versions slip10.Versions
+	// Path is the derivation path from Key to the described key, excluding the wildcard.
+	Path bip32path.Path
+	// Wildcard specifies the derivation step following Path.
+	Wildcard Wildcard
+}
+
+// NewKeyExpression creates a key expression for the extended public key derived from the master key at path.
+// The key origin is set accordingly. The returned key expression is not ranged.
+func NewKeyExpression(master *slip10.ExtendedKey, path bip32path.Path) (*KeyExpression, error) {
+	key := master
+	for _, i := range path {
+		child, err := key.DeriveChild(i)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive %s: %w", path, err)
+		}
+		key = child
+	}
+	return &KeyExpression{
+		Origin: &KeyOrigin{
+			Fingerprint: master.KeyFingerprint(),
+			Path:        append(bip32path.Path{}, path...),
+		},
+		Key:      key.Public(),
+		Versions: slip10.Mainnet,
+	}, nil
+}
+
+// ParseKeyExpression parses s as a key expression with an extended key for the given curve.
+func ParseKeyExpression(s string, curve slip10.Curve) (*KeyExpression, error) {
+	k := &KeyExpression{}
+
+	// parse the optional key origin
+	if strings.HasPrefix(s, "[") {
+		end := strings.IndexByte(s, ']')
+		if end < 0 {
+			return nil, fmt.Errorf("%w: key origin is not closed", ErrInvalidSyntax)
+		}
+		origin, err := parseKeyOrigin(s[1:end])
+		if err != nil {
+			return nil, err
+		}
+		k.Origin = origin
+		s = s[end+1:]
+	}
+
+	elements := strings.Split(s, "/")
+	// parse the optional wildcard
+	switch elements[len(elements)-1] {
+	case "*":
+		k.Wildcard = UnhardenedWildcard
+		elements = elements[:len(elements)-1]
+	case "*'", "*h", "*H":
+		k.Wildcard = HardenedWildcard
+		elements = elements[:len(elements)-1]
+	}
+	if len(elements) == 0 {
+		return nil, fmt.Errorf("%w: missing key", ErrInvalidSyntax)
+	}
+
+	key, versions, err := parseExtendedKey(elements[0], curve)
+	if err != nil {
+		return nil, err
+	}
+	k.Key, k.Versions = key, versions
+
+	k.Path, err = parsePathElements(elements[1:])
+	if err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// IsRange reports whether the key expression contains a wildcard.
+func (k *KeyExpression) IsRange() bool {
+	return k.Wildcard != NoWildcard
+}
+
+// Derive returns the extended public key described by the key expression.
+// For ranged key expressions, index is used for the wildcard, otherwise it is ignored.
+// If the origin of the key expression is consistent with the extended key, the full derivation path of the returned
+// key is set.
+func (k *KeyExpression) Derive(index uint32) (*slip10.ExtendedKey, error) {
+	path := append(bip32path.Path{}, k.Path...)
+	switch k.Wildcard {
+	case UnhardenedWildcard:
+		path = append(path, index)
+	case HardenedWildcard:
+		path = append(path, index|slip10.Hardened)
+	}
+
+	key := k.Key
+	for _, i := range path {
+		child, err := key.DeriveChild(i)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive %s: %w", k, err)
+		}
+		key = child
+	}
+	key = key.Public()
+
+	// add the full path if it is known
+	if k.Origin != nil && len(k.Origin.Path) == k.Key.Depth() {
+		full := append(append(bip32path.Path{}, k.Origin.Path...), path...)
+		if withPath, err := key.WithPath(full); err == nil {
+			key = withPath
+		}
+	}
+	return key, nil
+}
+
+// String returns the key expression in the form "[d34db33f/44'/0'/0']xpub.../1/*".
+func (k *KeyExpression) String() string {
+	var b strings.Builder
+	if k.Origin != nil {
+		b.WriteByte('[')
+		b.WriteString(k.Origin.String())
+		b.WriteByte(']')
+	}
+	key, err := k.Key.Serialize(k.Versions)
+	if err != nil {
+		key = "%!(" + err.Error() + ")"
+	}
+	b.WriteString(key)
+	b.WriteString(pathSuffix(k.Path))
+	switch k.Wildcard {
+	case UnhardenedWildcard:
+		b.WriteString("/*")
+	case HardenedWildcard:
+		b.WriteString("/*'")
+	}
+	return b.String()
+}
+
+func parseKeyOrigin(s string) (*KeyOrigin, error) {
+	elements := strings.Split(s, "/")
+	if len(elements[0]) != 2*slip10.FingerprintSize {
+		return nil, fmt.Errorf("%w: fingerprint must be %d hex characters", ErrInvalidSyntax, 2*slip10.FingerprintSize)
+	}
+	fingerprint, err := hex.DecodeString(elements[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid fingerprint: %s", ErrInvalidSyntax, err)
+	}
+	path, err := parsePathElements(elements[1:])
+	if err != nil {
+		return nil, err
+	}
+	return &KeyOrigin{Fingerprint: fingerprint, Path: path}, nil
+}
+
+// parsePathElements parses the elements of a path, each element can be hardened using ', h or H.
+func parsePathElements(elements []string) (bip32path.Path, error) {
+	if len(elements) == 0 {
+		return bip32path.Path{}, nil
+	}
+	normalized := make([]string, len(elements))
+	for i, element := range elements {
+		// empty elements are invalid and the master prefix is only allowed in bip32path
+		if element == "" || element == "m" {
+			return nil, fmt.Errorf("%w: invalid path element %q", ErrInvalidSyntax, element)
+		}
+		// bip32path does not support the lower case h
+		if strings.HasSuffix(element, "h") {
+			element = strings.TrimSuffix(element, "h") + "'"
+		}
+		normalized[i] = element
+	}
+	path, err := bip32path.ParsePath(strings.Join(normalized, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSyntax, err)
+	}
+	return path, nil
+}
+
+// parseExtendedKey parses the serialized extended key s and returns the key and the versions used.
+func parseExtendedKey(s string, curve slip10.Curve) (*slip10.ExtendedKey, slip10.Versions, error) {
+	key, err := slip10.ParseExtendedKey(s, curve)
+	if err != nil {
+		return nil, slip10.Versions{}, fmt.Errorf("%w: %s", ErrInvalidKey, err)
+	}
+	// keep the versions, so that the serialization is consistent
+	versions := slip10.Mainnet
+	if buf, err := base58.CheckDecode(s); err == nil && len(buf) >= 4 {
+		v := binary.BigEndian.Uint32(buf)
+		if v == slip10.Testnet.Private || v == slip10.Testnet.Public {
+			versions = slip10.Testnet
+		}
+	}
+	return key, versions, nil
+}
+
+// pathSuffix returns path in the form "/44'/0'/0'" or the empty string for an empty path.
+func pathSuffix(path bip32path.Path) string {
+	return strings.TrimPrefix(path.String(), "m")
+}
@@ -15,6 +15,7 @@ import (
 	"strconv"
 
 	"filippo.io/edwards25519"
+	"github.com/wollac/iota-crypto-demo/internal/wipe"
 )
 
 const (
@@ -71,6 +72,11 @@ func (priv PrivateKey) Seed() []byte {
 	return seed
 }
 
+// Wipe overwrites priv with zeros. The key must not be used afterwards.
+func (priv PrivateKey) Wipe() {
+	wipe.Bytes(priv)
+}
+
 // Sign signs the given message with priv.
 // Ed25519 performs two passes over messages to be signed and therefore cannot
 // handle pre-hashed messages. Thus opts.HashFunc() must return zero to
@@ -153,6 +159,10 @@ func sign(signature, privateKey, message []byte) {
 	}
 	prefix := h[32:]
 
+	signWithScalar(signature, s, prefix, publicKey, message)
+}
+
+func signWithScalar(signature []byte, s *edwards25519.Scalar, prefix, publicKey, message []byte) {
 	mh := sha512.New()
 	mh.Write(prefix)
 	mh.Write(message)
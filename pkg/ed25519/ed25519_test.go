@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"compress/gzip"
 	std "crypto/ed25519"
+	"crypto/sha512"
 	"encoding/csv"
 	"encoding/hex"
 	"math/rand"
@@ -125,6 +126,36 @@ func TestSTDLib(t *testing.T) {
 	}
 }
 
+func TestSignExtended(t *testing.T) {
+	rand.Seed(1)
+	seed := make([]byte, ed25519.SeedSize)
+	for i := 0; i < 100; i++ {
+		rand.Read(seed)
+		pub, priv, _ := ed25519.GenerateKey(bytes.NewReader(seed))
+
+		// the RFC 8032 expansion of the seed is a valid extended private key
+		extendedKey := sha512.Sum512(seed)
+		extendedKey[0] &= 248
+		extendedKey[31] &= 127
+		extendedKey[31] |= 64
+		assert.EqualValuesf(t, pub, ed25519.ExtendedPublicKey(extendedKey[:]), "different public key")
+
+		message := []byte("test message")
+		sig := ed25519.SignExtended(extendedKey[:], message)
+		assert.Equalf(t, ed25519.Sign(priv, message), sig, "different signature")
+		assert.Truef(t, ed25519.Verify(pub, message, sig), "invalid signature")
+	}
+}
+
+func TestPrivateKeyWipe(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	require.NotEqual(t, make([]byte, ed25519.PrivateKeySize), []byte(priv))
+
+	priv.Wipe()
+	assert.Equal(t, make([]byte, ed25519.PrivateKeySize), []byte(priv))
+}
+
 func BenchmarkSign(b *testing.B) {
 	_, privateKey, _ := ed25519.GenerateKey(nil)
 	data := make([][64]byte, b.N)
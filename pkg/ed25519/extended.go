@@ -0,0 +1,50 @@
+package ed25519
+
+import (
+	"strconv"
+
+	"filippo.io/edwards25519"
+)
+
+// ExtendedPrivateKeySize is the size, in bytes, of extended private keys as used in BIP32-Ed25519.
+// An extended private key consists of the 32-byte secret scalar kL followed by the 32-byte nonce prefix kR,
+// i.e. it corresponds to the SHA-512 hash of an RFC 8032 private key with the scalar already clamped.
+const ExtendedPrivateKeySize = 64
+
+// ExtendedPublicKey returns the PublicKey corresponding to the extended private key.
+// It will panic if len(extendedKey) is not ExtendedPrivateKeySize.
+func ExtendedPublicKey(extendedKey []byte) PublicKey {
+	if l := len(extendedKey); l != ExtendedPrivateKeySize {
+		panic("ed25519: bad extended private key length: " + strconv.Itoa(l))
+	}
+	A := (&edwards25519.Point{}).ScalarBaseMult(extendedScalar(extendedKey))
+	return A.Bytes()
+}
+
+// SignExtended signs the message with the extended private key and returns a signature.
+// The resulting signature can be verified with Verify and the public key returned by ExtendedPublicKey.
+// It will panic if len(extendedKey) is not ExtendedPrivateKeySize.
+func SignExtended(extendedKey []byte, message []byte) []byte {
+	if l := len(extendedKey); l != ExtendedPrivateKeySize {
+		panic("ed25519: bad extended private key length: " + strconv.Itoa(l))
+	}
+	s := extendedScalar(extendedKey)
+	prefix := extendedKey[32:]
+	publicKey := (&edwards25519.Point{}).ScalarBaseMult(s).Bytes()
+
+	signature := make([]byte, SignatureSize)
+	signWithScalar(signature, s, prefix, publicKey, message)
+	return signature
+}
+
+// extendedScalar returns the secret scalar kL of the extended key reduced modulo the group order.
+// In contrast to RFC 8032, no clamping is applied as kL might exceed 2^255 after a BIP32-Ed25519 derivation.
+func extendedScalar(extendedKey []byte) *edwards25519.Scalar {
+	var wide [64]byte
+	copy(wide[:], extendedKey[:32])
+	s, err := edwards25519.NewScalar().SetUniformBytes(wide[:])
+	if err != nil {
+		panic("ed25519: internal error: setting scalar failed")
+	}
+	return s
+}
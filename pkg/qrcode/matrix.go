@@ -0,0 +1,326 @@
+package qrcode
+
+// numMasks is the number of data mask patterns.
+const numMasks = 8
+
+// penalty weights of the mask evaluation
+const (
+	penaltyN1 = 3
+	penaltyN2 = 3
+	penaltyN3 = 40
+	penaltyN4 = 10
+)
+
+// matrix is the grid of modules under construction.
+type matrix struct {
+	modules [][]bool
+	// function[y][x] is true for modules of the function patterns and the format and version information
+	function [][]bool
+}
+
+func newMatrix(n int) *matrix {
+	m := &matrix{modules: make([][]bool, n), function: make([][]bool, n)}
+	for i := 0; i < n; i++ {
+		m.modules[i] = make([]bool, n)
+		m.function[i] = make([]bool, n)
+	}
+	return m
+}
+
+func (m *matrix) size() int {
+	return len(m.modules)
+}
+
+// setFunction sets the module in column x and row y and marks it as function module.
+func (m *matrix) setFunction(x, y int, dark bool) {
+	m.modules[y][x] = dark
+	m.function[y][x] = true
+}
+
+// draw places the codewords into the symbol. If mask is negative, the mask with the lowest penalty is applied.
+func (q *QRCode) draw(codewords []byte, mask int) {
+	m := newMatrix(size(q.version))
+	m.drawFunctionPatterns(q.version)
+	m.drawCodewords(codewords)
+
+	if mask < 0 {
+		minPenalty := -1
+		for i := 0; i < numMasks; i++ {
+			m.applyMask(i)
+			m.drawFormatBits(q.level, i)
+			if p := m.penalty(); minPenalty < 0 || p < minPenalty {
+				mask, minPenalty = i, p
+			}
+			// masks are self-inverse
+			m.applyMask(i)
+		}
+	}
+	m.applyMask(mask)
+	m.drawFormatBits(q.level, mask)
+
+	q.mask = mask
+	q.modules = m.modules
+}
+
+// drawFunctionPatterns draws the finder, timing and alignment patterns as well as the version information.
+// The modules of the format information are reserved.
+func (m *matrix) drawFunctionPatterns(version int) {
+	n := m.size()
+	for i := 0; i < n; i++ {
+		m.setFunction(6, i, i%2 == 0)
+		m.setFunction(i, 6, i%2 == 0)
+	}
+
+	m.drawFinderPattern(3, 3)
+	m.drawFinderPattern(n-4, 3)
+	m.drawFinderPattern(3, n-4)
+
+	positions := alignmentPatternPositions(version)
+	last := len(positions) - 1
+	for i, y := range positions {
+		for j, x := range positions {
+			// skip the positions overlapping the finder patterns
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			m.drawAlignmentPattern(x, y)
+		}
+	}
+
+	// reserve the format information, the actual bits are drawn after masking
+	m.drawFormatBits(Low, 0)
+	m.drawVersion(version)
+}
+
+// drawFinderPattern draws the finder pattern including the separator centered at x, y.
+func (m *matrix) drawFinderPattern(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || yy < 0 || xx >= m.size() || yy >= m.size() {
+				continue
+			}
+			d := chebyshev(dx, dy)
+			m.setFunction(xx, yy, d != 2 && d != 4)
+		}
+	}
+}
+
+// drawAlignmentPattern draws the alignment pattern centered at x, y.
+func (m *matrix) drawAlignmentPattern(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			m.setFunction(x+dx, y+dy, chebyshev(dx, dy) != 1)
+		}
+	}
+}
+
+// drawFormatBits draws both copies of the format information and the dark module.
+func (m *matrix) drawFormatBits(level Level, mask int) {
+	data := level.formatBits()<<3 | uint(mask)
+	// BCH(15, 5) code with the generator x¹⁰ + x⁸ + x⁵ + x⁴ + x² + x + 1
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+	bit := func(i int) bool { return (bits>>i)&1 != 0 }
+
+	// first copy around the top left finder pattern
+	for i := 0; i <= 5; i++ {
+		m.setFunction(8, i, bit(i))
+	}
+	m.setFunction(8, 7, bit(6))
+	m.setFunction(8, 8, bit(7))
+	m.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		m.setFunction(14-i, 8, bit(i))
+	}
+
+	// second copy split between the top right and the bottom left finder pattern
+	n := m.size()
+	for i := 0; i < 8; i++ {
+		m.setFunction(n-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		m.setFunction(8, n-15+i, bit(i))
+	}
+	m.setFunction(8, n-8, true)
+}
+
+// drawVersion draws both copies of the version information, which is only present in versions 7 and above.
+func (m *matrix) drawVersion(version int) {
+	if version < 7 {
+		return
+	}
+	// BCH(18, 6) code with the generator x¹² + x¹¹ + x¹⁰ + x⁹ + x⁸ + x⁵ + x² + 1
+	rem := uint(version)
+	for i := 0; i < 12; i++ {
+		rem = rem<<1 ^ (rem>>11)*0x1f25
+	}
+	bits := uint(version)<<12 | rem
+
+	n := m.size()
+	for i := 0; i < 18; i++ {
+		dark := (bits>>i)&1 != 0
+		a, b := n-11+i%3, i/3
+		m.setFunction(a, b, dark)
+		m.setFunction(b, a, dark)
+	}
+}
+
+// drawCodewords places the bits of the codewords in the zigzag pattern, starting at the bottom right corner in
+// columns of two modules. Any remaining modules stay light.
+func (m *matrix) drawCodewords(codewords []byte) {
+	n := m.size()
+	i := 0
+	for right := n - 1; right >= 1; right -= 2 {
+		// skip the vertical timing pattern
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < n; vert++ {
+			y := vert
+			if upward {
+				y = n - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if m.function[y][x] || i >= 8*len(codewords) {
+					continue
+				}
+				m.modules[y][x] = (codewords[i/8]>>(7-i%8))&1 != 0
+				i++
+			}
+		}
+	}
+}
+
+// applyMask inverts all modules not belonging to a function pattern for which the mask condition holds.
+func (m *matrix) applyMask(mask int) {
+	for y := range m.modules {
+		for x := range m.modules[y] {
+			if !m.function[y][x] && maskCondition(mask, x, y) {
+				m.modules[y][x] = !m.modules[y][x]
+			}
+		}
+	}
+}
+
+// maskCondition returns whether the mask pattern inverts the module in column x and row y.
+func maskCondition(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	case 7:
+		return ((x+y)%2+x*y%3)%2 == 0
+	default:
+		panic("invalid mask")
+	}
+}
+
+// penalty computes the penalty score of the masked symbol according to the four rules of the specification.
+func (m *matrix) penalty() int {
+	n := m.size()
+	at := func(x, y int, transpose bool) bool {
+		if transpose {
+			return m.modules[x][y]
+		}
+		return m.modules[y][x]
+	}
+
+	score, dark := 0, 0
+	for _, transpose := range []bool{false, true} {
+		for y := 0; y < n; y++ {
+			// N1: runs of five or more modules of the same color in a row or column
+			run := 1
+			for x := 1; x <= n; x++ {
+				if x < n && at(x, y, transpose) == at(x-1, y, transpose) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					score += penaltyN1 + run - 5
+				}
+				run = 1
+			}
+			// N3: the finder-like pattern 1:1:3:1:1 preceded or followed by four light modules
+			for x := 0; x+11 <= n; x++ {
+				if matchesFinderLike(func(i int) bool { return at(x+i, y, transpose) }) {
+					score += penaltyN3
+				}
+			}
+		}
+	}
+
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			c := m.modules[y][x]
+			if c {
+				dark++
+			}
+			// N2: blocks of 2×2 modules of the same color
+			if x+1 < n && y+1 < n && c == m.modules[y][x+1] && c == m.modules[y+1][x] && c == m.modules[y+1][x+1] {
+				score += penaltyN2
+			}
+		}
+	}
+
+	// N4: deviation of the proportion of dark modules from 50% in steps of 5%
+	total := n * n
+	deviation := dark*20 - total*10
+	if deviation < 0 {
+		deviation = -deviation
+	}
+	score += deviation / total * penaltyN4
+	return score
+}
+
+// finderLike contains the pattern dark-light-dark-dark-dark-light-dark with four light modules on either side.
+var finderLike = [2][11]bool{
+	{true, false, true, true, true, false, true, false, false, false, false},
+	{false, false, false, false, true, false, true, true, true, false, true},
+}
+
+func matchesFinderLike(at func(int) bool) bool {
+	for _, pattern := range finderLike {
+		match := true
+		for i, dark := range pattern {
+			if at(i) != dark {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// chebyshev returns the Chebyshev distance of dx, dy from the origin.
+func chebyshev(dx, dy int) int {
+	if dx < 0 {
+		dx = -dx
+	}
+	if dy < 0 {
+		dy = -dy
+	}
+	if dx > dy {
+		return dx
+	}
+	return dy
+}
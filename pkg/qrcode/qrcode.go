@@ -0,0 +1,175 @@
+/*
+Package qrcode implements a QR code generator as specified in ISO/IEC 18004.
+
+The content is encoded in a single segment using the most compact of the numeric, alphanumeric and byte mode, or
+always in byte mode when using EncodeBytes. The smallest version that fits the content at the requested error
+correction level is selected, as well as the mask with the lowest penalty score.
+The resulting symbol can be rendered as an image, e.g. to be written as PNG, or as block characters for terminals.
+*/
+package qrcode
+
+import (
+	"errors"
+)
+
+// Level is the error correction level of a QR code.
+type Level int
+
+// Error correction levels, which can restore approximately 7%, 15%, 25% and 30% of the codewords respectively.
+const (
+	Low Level = iota
+	Medium
+	Quartile
+	High
+)
+
+// formatBits returns the two bits encoding the level in the format information.
+func (l Level) formatBits() uint {
+	return [...]uint{1, 0, 3, 2}[l]
+}
+
+// ErrDataTooLong is returned when the content does not fit into the largest QR code.
+var ErrDataTooLong = errors.New("data too long")
+
+// errInvalidLevel is used as panic value for unknown error correction levels.
+var errInvalidLevel = errors.New("invalid error correction level")
+
+// QRCode represents a QR code symbol.
+type QRCode struct {
+	version int
+	level   Level
+	mask    int
+	// modules[y][x] is true for a dark module
+	modules [][]bool
+}
+
+// Encode encodes content in the most compact mode and returns the smallest QR code at the given level.
+func Encode(content string, level Level) (*QRCode, error) {
+	var seg segment
+	switch {
+	case isNumeric(content):
+		seg = numericSegment(content)
+	case isAlphanumeric(content):
+		seg = alphanumericSegment(content)
+	default:
+		seg = byteSegment([]byte(content))
+	}
+	return encode(seg, level, -1)
+}
+
+// EncodeBytes encodes data in byte mode and returns the smallest QR code at the given level.
+func EncodeBytes(data []byte, level Level) (*QRCode, error) {
+	return encode(byteSegment(data), level, -1)
+}
+
+// encode creates the QR code of the segment, if mask is negative the mask with the lowest penalty is used.
+func encode(seg segment, level Level, mask int) (*QRCode, error) {
+	if level < Low || level > High {
+		panic(errInvalidLevel)
+	}
+	segments := []segment{seg}
+
+	version, bits := minVersion, -1
+	for ; version <= maxVersion; version++ {
+		bits = totalBits(segments, version)
+		if bits >= 0 && bits <= 8*numDataCodewords(version, level) {
+			break
+		}
+	}
+	if version > maxVersion {
+		return nil, ErrDataTooLong
+	}
+
+	var buf bitBuffer
+	for _, s := range segments {
+		buf.appendBits(s.mode.indicator, 4)
+		buf.appendBits(uint(s.count), s.mode.numCountBits(version))
+		buf = append(buf, s.data...)
+	}
+	// add the terminator and pad to full bytes
+	capacity := 8 * numDataCodewords(version, level)
+	terminator := capacity - len(buf)
+	if terminator > 4 {
+		terminator = 4
+	}
+	buf.appendBits(0, terminator)
+	buf.appendBits(0, (8-len(buf)%8)%8)
+
+	data := make([]byte, capacity/8)
+	for i, bit := range buf {
+		if bit {
+			data[i/8] |= 1 << (7 - i%8)
+		}
+	}
+	// fill the remaining capacity with the alternating pad codewords
+	for i, pad := len(buf)/8, byte(0xec); i < len(data); i, pad = i+1, pad^0xec^0x11 {
+		data[i] = pad
+	}
+
+	q := &QRCode{version: version, level: level}
+	q.draw(addErrorCorrection(data, version, level), mask)
+	return q, nil
+}
+
+// addErrorCorrection splits the data codewords into blocks, computes the error correction codewords of each block
+// and returns all codewords interleaved.
+func addErrorCorrection(data []byte, version int, level Level) []byte {
+	numBlocks := numErrorCorrectionBlocks[level][version]
+	eccLen := eccCodewordsPerBlock[level][version]
+	rawCodewords := numRawDataModules(version) / 8
+	// the last blocks contain one more data codeword than the first numShortBlocks blocks
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortDataLen := rawCodewords/numBlocks - eccLen
+
+	gen := reedSolomonGenerator(eccLen)
+	dataBlocks := make([][]byte, numBlocks)
+	eccBlocks := make([][]byte, numBlocks)
+	for i, k := 0, 0; i < numBlocks; i++ {
+		n := shortDataLen
+		if i >= numShortBlocks {
+			n++
+		}
+		dataBlocks[i] = data[k : k+n]
+		eccBlocks[i] = reedSolomonRemainder(dataBlocks[i], gen)
+		k += n
+	}
+
+	result := make([]byte, 0, rawCodewords)
+	for i := 0; i <= shortDataLen; i++ {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < eccLen; i++ {
+		for _, block := range eccBlocks {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+// Version returns the version of the QR code in [1, 40].
+func (q *QRCode) Version() int {
+	return q.version
+}
+
+// Level returns the error correction level of the QR code.
+func (q *QRCode) Level() Level {
+	return q.level
+}
+
+// Size returns the number of modules per side of the QR code, excluding the quiet zone.
+func (q *QRCode) Size() int {
+	return len(q.modules)
+}
+
+// Dark returns whether the module in column x and row y is dark. It returns false for coordinates outside of the
+// symbol, i.e. in the quiet zone.
+func (q *QRCode) Dark(x, y int) bool {
+	if x < 0 || y < 0 || x >= q.Size() || y >= q.Size() {
+		return false
+	}
+	return q.modules[y][x]
+}
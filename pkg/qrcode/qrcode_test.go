@@ -0,0 +1,133 @@
+package qrcode_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wollac/iota-crypto-demo/pkg/qrcode"
+)
+
+var levels = map[string]qrcode.Level{"L": qrcode.Low, "M": qrcode.Medium, "Q": qrcode.Quartile, "H": qrcode.High}
+
+type Test struct {
+	Content string   `json:"content"`
+	Level   string   `json:"level"`
+	Version int      `json:"version"`
+	Modules []string `json:"modules"`
+}
+
+func TestEncode(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("testdata", t.Name()+".json"))
+	require.NoError(t, err)
+	var tests []Test
+	require.NoError(t, json.Unmarshal(b, &tests))
+
+	for _, tt := range tests {
+		t.Run(tt.Content, func(t *testing.T) {
+			q, err := qrcode.Encode(tt.Content, levels[tt.Level])
+			require.NoError(t, err)
+			assert.Equal(t, tt.Version, q.Version())
+			assert.Equal(t, levels[tt.Level], q.Level())
+			assert.Equal(t, tt.Modules, modules(q))
+		})
+	}
+}
+
+func TestEncodeBytes(t *testing.T) {
+	var tests = []*struct {
+		size    int
+		level   qrcode.Level
+		version int
+	}{
+		{16, qrcode.Low, 1},
+		{17, qrcode.Low, 1},
+		{18, qrcode.Low, 2},
+		{32, qrcode.Low, 2},
+		{32, qrcode.High, 4},
+		{2953, qrcode.Low, 40},
+	}
+	for _, tt := range tests {
+		q, err := qrcode.EncodeBytes(bytes.Repeat([]byte{'1'}, tt.size), tt.level)
+		require.NoError(t, err)
+		assert.Equal(t, tt.version, q.Version())
+		assert.Equal(t, 4*tt.version+17, q.Size())
+	}
+
+	// digits are not encoded in numeric mode
+	q, err := qrcode.Encode(strings.Repeat("1", 32), qrcode.Low)
+	require.NoError(t, err)
+	assert.Equal(t, 1, q.Version())
+}
+
+func TestEncodeTooLong(t *testing.T) {
+	_, err := qrcode.EncodeBytes(make([]byte, 2954), qrcode.Low)
+	assert.ErrorIs(t, err, qrcode.ErrDataTooLong)
+	_, err = qrcode.Encode(strings.Repeat("1", 7090), qrcode.Low)
+	assert.ErrorIs(t, err, qrcode.ErrDataTooLong)
+}
+
+func TestWritePNG(t *testing.T) {
+	const scale = 3
+	q, err := qrcode.Encode("HELLO WORLD", qrcode.Quartile)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, q.WritePNG(&buf, scale))
+	img, err := png.Decode(&buf)
+	require.NoError(t, err)
+
+	n := (q.Size() + 2*qrcode.QuietZone) * scale
+	require.Equal(t, n, img.Bounds().Dx())
+	require.Equal(t, n, img.Bounds().Dy())
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			r, _, _, _ := img.At(x, y).RGBA()
+			assert.Equal(t, q.Dark(x/scale-qrcode.QuietZone, y/scale-qrcode.QuietZone), r == 0)
+		}
+	}
+}
+
+func TestTerminal(t *testing.T) {
+	q, err := qrcode.Encode("HELLO WORLD", qrcode.Quartile)
+	require.NoError(t, err)
+	n := q.Size() + 2*qrcode.QuietZone
+
+	for _, inverse := range []bool{false, true} {
+		lines := strings.Split(strings.TrimSuffix(q.Terminal(inverse), "\n"), "\n")
+		require.Len(t, lines, (n+1)/2)
+		for _, line := range lines {
+			assert.Equal(t, n, utf8.RuneCountInString(line))
+		}
+		// the third line contains the first two rows of the top left finder pattern
+		quiet, finder := " ", "█▀▀▀▀▀█"
+		if inverse {
+			quiet, finder = "█", " ▄▄▄▄▄ "
+		}
+		assert.Equal(t, strings.Repeat(quiet, n), lines[0])
+		assert.True(t, strings.HasPrefix(lines[2], strings.Repeat(quiet, qrcode.QuietZone)+finder))
+	}
+}
+
+func modules(q *qrcode.QRCode) []string {
+	rows := make([]string, q.Size())
+	for y := range rows {
+		var sb strings.Builder
+		for x := 0; x < q.Size(); x++ {
+			if q.Dark(x, y) {
+				sb.WriteByte('1')
+			} else {
+				sb.WriteByte('0')
+			}
+		}
+		rows[y] = sb.String()
+	}
+	return rows
+}
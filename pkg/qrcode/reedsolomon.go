@@ -0,0 +1,51 @@
+package qrcode
+
+// the field GF(2⁸) uses the reducing polynomial x⁸ + x⁴ + x³ + x² + 1
+const gfPoly = 0x11d
+
+// gfMul returns the product of x and y in GF(2⁸).
+func gfMul(x, y byte) byte {
+	var z byte
+	for i := 7; i >= 0; i-- {
+		// multiply z by x and reduce
+		z = z<<1 ^ byte(-(int(z>>7))&(gfPoly&0xff))
+		if (y>>i)&1 != 0 {
+			z ^= x
+		}
+	}
+	return z
+}
+
+// reedSolomonGenerator returns the coefficients of the generator polynomial ∏ (x - αⁱ) for i in [0, degree),
+// excluding the leading coefficient 1, from the highest to the lowest power.
+func reedSolomonGenerator(degree int) []byte {
+	gen := make([]byte, degree)
+	gen[degree-1] = 1 // start with the monomial x⁰
+
+	// multiply by (x - αⁱ), which is (x + αⁱ) in GF(2⁸)
+	var root byte = 1
+	for i := 0; i < degree; i++ {
+		for j := range gen {
+			gen[j] = gfMul(gen[j], root)
+			if j+1 < len(gen) {
+				gen[j] ^= gen[j+1]
+			}
+		}
+		root = gfMul(root, 0x02)
+	}
+	return gen
+}
+
+// reedSolomonRemainder returns the error correction codewords of data for the given generator.
+func reedSolomonRemainder(data []byte, gen []byte) []byte {
+	rem := make([]byte, len(gen))
+	for _, b := range data {
+		factor := b ^ rem[0]
+		copy(rem, rem[1:])
+		rem[len(rem)-1] = 0
+		for i := range rem {
+			rem[i] ^= gfMul(gen[i], factor)
+		}
+	}
+	return rem
+}
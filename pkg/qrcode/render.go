@@ -0,0 +1,60 @@
+package qrcode
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+)
+
+// QuietZone is the number of light modules surrounding the symbol on each side.
+const QuietZone = 4
+
+// Image returns a black and white image of the QR code including the quiet zone, where each module is drawn as a
+// square of scale×scale pixels.
+func (q *QRCode) Image(scale int) image.Image {
+	if scale < 1 {
+		scale = 1
+	}
+	n := (q.Size() + 2*QuietZone) * scale
+	img := image.NewPaletted(image.Rect(0, 0, n, n), color.Palette{color.White, color.Black})
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			if q.Dark(x/scale-QuietZone, y/scale-QuietZone) {
+				img.SetColorIndex(x, y, 1)
+			}
+		}
+	}
+	return img
+}
+
+// WritePNG writes the QR code as PNG image to w. Each module is drawn as a square of scale×scale pixels.
+func (q *QRCode) WritePNG(w io.Writer, scale int) error {
+	return png.Encode(w, q.Image(scale))
+}
+
+// Terminal returns the QR code including the quiet zone as lines of Unicode block characters, where each character
+// represents two vertically adjacent modules. Dark modules are drawn as blocks, if inverse is false. Terminals with a
+// dark background usually require inverse to be true.
+func (q *QRCode) Terminal(inverse bool) string {
+	blocks := [4]string{" ", "▄", "▀", "█"}
+	var sb strings.Builder
+	for y := -QuietZone; y < q.Size()+QuietZone; y += 2 {
+		for x := -QuietZone; x < q.Size()+QuietZone; x++ {
+			upper := q.Dark(x, y) != inverse
+			// the module below the last row belongs to the quiet zone
+			lower := y+1 < q.Size()+QuietZone && q.Dark(x, y+1) != inverse
+			i := 0
+			if upper {
+				i |= 2
+			}
+			if lower {
+				i |= 1
+			}
+			sb.WriteString(blocks[i])
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
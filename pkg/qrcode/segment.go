@@ -0,0 +1,123 @@
+package qrcode
+
+import (
+	"strings"
+)
+
+// mode is the encoding mode of a segment.
+type mode struct {
+	indicator uint
+	// number of bits of the character count for the versions 1-9, 10-26 and 27-40
+	countBits [3]int
+}
+
+var (
+	modeNumeric      = mode{0x1, [3]int{10, 12, 14}}
+	modeAlphanumeric = mode{0x2, [3]int{9, 11, 13}}
+	modeByte         = mode{0x4, [3]int{8, 16, 16}}
+)
+
+func (m mode) numCountBits(version int) int {
+	switch {
+	case version <= 9:
+		return m.countBits[0]
+	case version <= 26:
+		return m.countBits[1]
+	default:
+		return m.countBits[2]
+	}
+}
+
+// alphanumericCharset contains the characters of the alphanumeric mode ordered by their value.
+const alphanumericCharset = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+// bitBuffer is a sequence of bits.
+type bitBuffer []bool
+
+// appendBits appends the n least significant bits of v, the most significant bit first.
+func (b *bitBuffer) appendBits(v uint, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*b = append(*b, (v>>i)&1 != 0)
+	}
+}
+
+// segment is a sequence of characters encoded in a single mode.
+type segment struct {
+	mode  mode
+	count int
+	data  bitBuffer
+}
+
+func isNumeric(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphanumeric(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(alphanumericCharset, s[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// numericSegment encodes the digits in groups of three.
+func numericSegment(digits string) segment {
+	var data bitBuffer
+	for i := 0; i < len(digits); i += 3 {
+		n := len(digits) - i
+		if n > 3 {
+			n = 3
+		}
+		var v uint
+		for _, c := range digits[i : i+n] {
+			v = v*10 + uint(c-'0')
+		}
+		// 1, 2 or 3 digits need 4, 7 or 10 bits respectively
+		data.appendBits(v, 3*n+1)
+	}
+	return segment{modeNumeric, len(digits), data}
+}
+
+// alphanumericSegment encodes the characters in pairs.
+func alphanumericSegment(s string) segment {
+	var data bitBuffer
+	for i := 0; i < len(s); i += 2 {
+		v := uint(strings.IndexByte(alphanumericCharset, s[i]))
+		if i+1 < len(s) {
+			v = v*45 + uint(strings.IndexByte(alphanumericCharset, s[i+1]))
+			data.appendBits(v, 11)
+		} else {
+			data.appendBits(v, 6)
+		}
+	}
+	return segment{modeAlphanumeric, len(s), data}
+}
+
+// byteSegment encodes each byte as is.
+func byteSegment(b []byte) segment {
+	data := make(bitBuffer, 0, 8*len(b))
+	for _, c := range b {
+		data.appendBits(uint(c), 8)
+	}
+	return segment{modeByte, len(b), data}
+}
+
+// totalBits returns the number of bits needed to encode the segments in the given version or -1, if the character
+// count of a segment does not fit.
+func totalBits(segments []segment, version int) int {
+	n := 0
+	for _, s := range segments {
+		countBits := s.mode.numCountBits(version)
+		if s.count >= 1<<countBits {
+			return -1
+		}
+		n += 4 + countBits + len(s.data)
+	}
+	return n
+}
@@ -0,0 +1,49 @@
+package ecdh
+
+import (
+	"crypto/sha512"
+	"errors"
+	"strconv"
+
+	"filippo.io/edwards25519"
+	"github.com/wollac/iota-crypto-demo/internal/wipe"
+	"github.com/wollac/iota-crypto-demo/pkg/ed25519"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/eddsa"
+)
+
+// ErrInvalidEd25519Key is returned when an Ed25519 public key cannot be converted into an X25519 public key.
+var ErrInvalidEd25519Key = errors.New("invalid Ed25519 public key")
+
+// PrivateKeyFromEd25519 converts the Ed25519 seed into the corresponding X25519 private key.
+// The X25519 scalar is the clamped first half of the SHA-512 hash of the seed, i.e. exactly the secret scalar that
+// RFC 8032 derives for Ed25519. Thus, the public key of the result equals the output of PublicKeyFromEd25519.
+// It will panic if len(seed) is not ed25519.SeedSize.
+func PrivateKeyFromEd25519(seed eddsa.Seed) PrivateKey {
+	if l := len(seed); l != ed25519.SeedSize {
+		panic("ecdh: bad Ed25519 seed length: " + strconv.Itoa(l))
+	}
+	h := sha512.Sum512(seed)
+	defer wipe.Bytes(h[:])
+
+	privateKey := make([]byte, PrivateKeySize)
+	copy(privateKey, h[:PrivateKeySize])
+	privateKey[0] &= 248
+	privateKey[31] &= 127
+	privateKey[31] |= 64
+	return privateKey
+}
+
+// PublicKeyFromEd25519 converts the Ed25519 public key into the corresponding X25519 public key using the
+// birational map u = (1 + y) / (1 - y) from the twisted Edwards curve to the Montgomery curve of RFC 7748.
+// It returns ErrInvalidEd25519Key if the key does not encode a point or if the point has a small order, since such a
+// point would lead to a predictable shared secret.
+func PublicKeyFromEd25519(publicKey ed25519.PublicKey) (PublicKey, error) {
+	A, err := new(edwards25519.Point).SetBytes(publicKey)
+	if err != nil {
+		return nil, ErrInvalidEd25519Key
+	}
+	if new(edwards25519.Point).MultByCofactor(A).Equal(edwards25519.NewIdentityPoint()) == 1 {
+		return nil, ErrInvalidEd25519Key
+	}
+	return A.BytesMontgomery(), nil
+}
@@ -0,0 +1,151 @@
+// Package ecdh provides slip10.Curve implementations for Diffie-Hellman key agreement.
+package ecdh
+
+import (
+	"errors"
+
+	"github.com/wollac/iota-crypto-demo/internal/wipe"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/internal/session"
+	"golang.org/x/crypto/curve25519"
+)
+
+const (
+	// PrivateKeySize is the size, in bytes, of X25519 private keys.
+	PrivateKeySize = curve25519.ScalarSize
+	// PublicKeySize is the size, in bytes, of X25519 public keys.
+	PublicKeySize = curve25519.PointSize
+)
+
+// ErrNotHardened is returned when a non-hardened derivation is attempted.
+var ErrNotHardened = errors.New("only hardened derivation is supported")
+
+type x25519Curve struct{}
+
+// X25519 returns a slip10.Curve which implements the X25519 function of Curve25519. See RFC 7748.
+func X25519() slip10.Curve {
+	return x25519Curve{}
+}
+
+func (x25519Curve) NewPrivateKey(buf []byte) (slip10.Key, error) {
+	if len(buf) != PrivateKeySize {
+		panic("invalid buffer length")
+	}
+
+	// any 32-byte string is a valid X25519 scalar
+	privateKey := make([]byte, PrivateKeySize)
+	copy(privateKey, buf)
+	return PrivateKey(privateKey), nil
+}
+
+// NewPublicKey creates a PublicKey from its SLIP-10 serialization, i.e. the 32-byte key prefixed with 0x00.
+func (x25519Curve) NewPublicKey(buf []byte) (slip10.Key, error) {
+	if len(buf) != slip10.PublicKeySize || buf[0] != 0x00 {
+		return nil, slip10.ErrInvalidKey
+	}
+
+	publicKey := make([]byte, PublicKeySize)
+	copy(publicKey, buf[1:])
+	return PublicKey(publicKey), nil
+}
+
+func (x25519Curve) Name() string {
+	return "curve25519"
+}
+
+func (x25519Curve) HmacKey() []byte {
+	return []byte("curve25519 seed")
+}
+
+// PrivateKey implements slip10.Key and represents an X25519 private key.
+type PrivateKey []byte
+
+// Bytes returns the SLIP-10 serialization of the key.
+func (k PrivateKey) Bytes() []byte {
+	return k
+}
+
+// IsPrivate always returns true.
+func (PrivateKey) IsPrivate() bool {
+	return true
+}
+
+// Public returns the corresponding PublicKey.
+func (k PrivateKey) Public() slip10.Key {
+	return k.X25519PublicKey()
+}
+
+// Wipe overwrites the key with zeros.
+func (k PrivateKey) Wipe() {
+	wipe.Bytes(k)
+}
+
+// Clone returns a copy of the key.
+func (k PrivateKey) Clone() slip10.Key {
+	return append(PrivateKey{}, k...)
+}
+
+// Shift derives a new PrivateKey from the provided bytes.
+func (PrivateKey) Shift(buf []byte) (slip10.Key, error) {
+	if len(buf) != PrivateKeySize {
+		panic("invalid buffer length")
+	}
+
+	privateKey := make([]byte, PrivateKeySize)
+	copy(privateKey, buf)
+	return PrivateKey(privateKey), nil
+}
+
+// X25519PublicKey returns the corresponding X25519 public key, i.e. the u-coordinate of the scalar multiplication
+// of the private key with the base point.
+func (k PrivateKey) X25519PublicKey() PublicKey {
+	publicKey, err := curve25519.X25519(k, curve25519.Basepoint)
+	if err != nil {
+		// this can never happen for the base point
+		panic(err)
+	}
+	return publicKey
+}
+
+// SharedSecret computes the X25519 shared secret between the private key and the peer's public key.
+// It returns an error, if the result is the all-zero value, i.e. if peer is a low order point.
+func (k PrivateKey) SharedSecret(peer PublicKey) ([]byte, error) {
+	return curve25519.X25519(k, peer)
+}
+
+// SessionKey derives a symmetric session key of the given size from the X25519 shared secret with the peer.
+// The key is derived using HKDF-SHA256 (RFC 5869) with the optional salt and the application specific info.
+func (k PrivateKey) SessionKey(peer PublicKey, salt, info []byte, size int) ([]byte, error) {
+	secret, err := k.SharedSecret(peer)
+	if err != nil {
+		return nil, err
+	}
+	defer wipe.Bytes(secret)
+	return session.Key(secret, salt, info, size)
+}
+
+// PublicKey implements slip10.Key and represents an X25519 public key.
+type PublicKey []byte
+
+// Bytes returns the SLIP-10 serialization of the key.
+func (p PublicKey) Bytes() []byte {
+	buf := make([]byte, slip10.PublicKeySize)
+	copy(buf[slip10.PublicKeySize-len(p):], p)
+	return buf
+}
+
+// IsPrivate always returns false.
+func (PublicKey) IsPrivate() bool {
+	return false
+}
+
+// Public returns a reference to itself.
+func (p PublicKey) Public() slip10.Key {
+	return p
+}
+
+// Shift implements the Shift method of slip10.Key.
+func (PublicKey) Shift([]byte) (slip10.Key, error) {
+	// as X25519 only supports hardened derivation, this is not supported
+	return nil, ErrNotHardened
+}
@@ -0,0 +1,110 @@
+package ecdh_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wollac/iota-crypto-demo/pkg/ed25519"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/ecdh"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/eddsa"
+)
+
+// test vectors of RFC 7748, section 5.2
+func TestSharedSecret(t *testing.T) {
+	var tests = []*struct {
+		key    ecdh.PrivateKey
+		peer   ecdh.PublicKey
+		secret []byte
+	}{
+		{
+			decode("a546e36bf0527c9d3b16154b82465edd62144c0ac1fc5a18506a2244ba449ac4"),
+			decode("e6db6867583030db3594c1a424b15f7c726624ec26b3353b10a903a6d0ab1c4c"),
+			decode("c3da55379de9c6908e94ea4df28d084f32eccf03491c71f754b4075577a28552"),
+		},
+		{
+			decode("4b66e9d4d1b4673c5ad22691957d6af5c11b6421e0ea01d42ca4169e7918ba0d"),
+			decode("e5210f12786811d3f4b7959d0538ae2c31dbe7106fc03c3efc4cd549c715a493"),
+			decode("95cbde9476e8907d7aade45cb4b873f88b595a68799fa152e6f8f7647aac7957"),
+		},
+	}
+	for _, tt := range tests {
+		secret, err := tt.key.SharedSecret(tt.peer)
+		require.NoError(t, err)
+		assert.Equal(t, tt.secret, secret)
+	}
+}
+
+// Diffie-Hellman test vector of RFC 7748, section 6.1
+func TestDiffieHellman(t *testing.T) {
+	alice := ecdh.PrivateKey(decode("77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a"))
+	bob := ecdh.PrivateKey(decode("5dab087e624a8a4b79e17f8b83800ee66f3bb1292618b6fd1c2f8b27ff88e0eb"))
+
+	assert.EqualValues(t, decode("8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a"), alice.X25519PublicKey())
+	assert.EqualValues(t, decode("de9edb7d7b7dc1b4d35b61c2ece435373f8343c85b78674dadfc7e146f882b4f"), bob.X25519PublicKey())
+
+	expected := decode("4a5d9d5ba4ce2de1728e3bf480350f25e07e21c947d19e3376f09b3c1e161742")
+	secret, err := alice.SharedSecret(bob.X25519PublicKey())
+	require.NoError(t, err)
+	assert.Equal(t, expected, secret)
+	secret, err = bob.SharedSecret(alice.X25519PublicKey())
+	require.NoError(t, err)
+	assert.Equal(t, expected, secret)
+
+	key1, err := alice.SessionKey(bob.X25519PublicKey(), nil, []byte("info"), 32)
+	require.NoError(t, err)
+	key2, err := bob.SessionKey(alice.X25519PublicKey(), nil, []byte("info"), 32)
+	require.NoError(t, err)
+	assert.Equal(t, key1, key2)
+	assert.Len(t, key1, 32)
+}
+
+func TestLowOrderPoint(t *testing.T) {
+	key := ecdh.PrivateKey(bytes.Repeat([]byte{0x01}, ecdh.PrivateKeySize))
+	// u = 0 is a point of small order
+	_, err := key.SharedSecret(make([]byte, ecdh.PublicKeySize))
+	assert.Error(t, err)
+	_, err = key.SessionKey(make([]byte, ecdh.PublicKeySize), nil, nil, 32)
+	assert.Error(t, err)
+}
+
+// test vector of libsodium's crypto_sign_ed25519_sk_to_curve25519 and crypto_sign_ed25519_pk_to_curve25519
+func TestEd25519Conversion(t *testing.T) {
+	seed := eddsa.Seed(decode("421151a459faeade3d247115f94aedae42318124095afabe4d1451a559faedee"))
+	publicKey, _ := seed.Ed25519Key()
+
+	privateKey := ecdh.PrivateKeyFromEd25519(seed)
+	assert.EqualValues(t, decode("8052030376d47112be7f73ed7a019293dd12ad910b654455798b4667d73de166"), privateKey)
+
+	x25519PublicKey, err := ecdh.PublicKeyFromEd25519(publicKey)
+	require.NoError(t, err)
+	assert.EqualValues(t, decode("f1814f0e8ff1043d8a44d25babff3cedcae6c22c3edaa48f857ae70de2baae50"), x25519PublicKey)
+	assert.Equal(t, privateKey.X25519PublicKey(), x25519PublicKey)
+}
+
+func TestEd25519ConversionInvalid(t *testing.T) {
+	// the identity point has small order
+	identity := make(ed25519.PublicKey, ed25519.PublicKeySize)
+	identity[0] = 0x01
+	_, err := ecdh.PublicKeyFromEd25519(identity)
+	assert.ErrorIs(t, err, ecdh.ErrInvalidEd25519Key)
+
+	// y = 2 does not correspond to a point
+	invalid := make(ed25519.PublicKey, ed25519.PublicKeySize)
+	invalid[0] = 0x02
+	_, err = ecdh.PublicKeyFromEd25519(invalid)
+	assert.ErrorIs(t, err, ecdh.ErrInvalidEd25519Key)
+
+	_, err = ecdh.PublicKeyFromEd25519(make(ed25519.PublicKey, 31))
+	assert.ErrorIs(t, err, ecdh.ErrInvalidEd25519Key)
+}
+
+func decode(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,251 @@
+package eddsa
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+
+	"filippo.io/edwards25519"
+	"github.com/wollac/iota-crypto-demo/internal/wipe"
+	"github.com/wollac/iota-crypto-demo/pkg/ed25519"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10"
+)
+
+// ErrShiftNotSupported is returned when Shift is called on a BIP32-Ed25519 key.
+// These keys implement slip10.ChildDeriver and must be derived using slip10.ExtendedKey.DeriveChild.
+var ErrShiftNotSupported = errors.New("additive shift is not supported by BIP32-Ed25519 keys")
+
+type bip32Ed25519Curve struct{}
+
+// BIP32Ed25519 returns a slip10.Curve which implements the BIP32-Ed25519 derivation of Ed25519 keys as proposed by
+// Khovratovich and Law. See https://input-output-hk.github.io/adrestia/static/Ed25519_BIP.pdf.
+// In contrast to Ed25519, it supports non-hardened derivation of both private and public keys.
+//
+// When used with slip10.NewMasterKey, I_L is used as the 256-bit root secret which is expanded using SHA-512.
+// Root secrets leading to an invalid extended private key are rejected and the generation is retried.
+// As the expansion matches RFC 8032, the resulting master public key is identical to the one of Ed25519.
+func BIP32Ed25519() slip10.Curve {
+	return bip32Ed25519Curve{}
+}
+
+func (bip32Ed25519Curve) NewPrivateKey(buf []byte) (slip10.Key, error) {
+	if len(buf) != ed25519.SeedSize {
+		panic("invalid buffer length")
+	}
+
+	// k = H512(k̃), discard k̃ if the third highest bit of the last byte of kL is not zero
+	k := sha512.Sum512(buf)
+	if k[31]&0x20 != 0 {
+		return nil, slip10.ErrInvalidKey
+	}
+	// clear the lowest three bits of the first byte and the highest bit of the last byte, set the second highest bit
+	k[0] &= 0xf8
+	k[31] &= 0x7f
+	k[31] |= 0x40
+	return ExtendedPrivateKey(k[:]), nil
+}
+
+// NewPublicKey creates an ExtendedPublicKey from its SLIP-10 serialization, i.e. the 32-byte key prefixed with 0x00.
+func (bip32Ed25519Curve) NewPublicKey(buf []byte) (slip10.Key, error) {
+	if len(buf) != slip10.PublicKeySize || buf[0] != 0x00 {
+		return nil, slip10.ErrInvalidKey
+	}
+	if _, err := new(edwards25519.Point).SetBytes(buf[1:]); err != nil {
+		return nil, slip10.ErrInvalidKey
+	}
+
+	publicKey := make([]byte, ed25519.PublicKeySize)
+	copy(publicKey, buf[1:])
+	return ExtendedPublicKey(publicKey), nil
+}
+
+func (bip32Ed25519Curve) Name() string {
+	return "ed25519-bip32"
+}
+
+func (bip32Ed25519Curve) HmacKey() []byte {
+	return []byte("ed25519 seed")
+}
+
+// ExtendedPrivateKey implements slip10.Key and represents a BIP32-Ed25519 extended private key.
+// It consists of the 32-byte secret scalar kL followed by the 32-byte nonce prefix kR.
+type ExtendedPrivateKey []byte
+
+// Bytes returns the 64-byte serialization kL || kR of the key.
+func (k ExtendedPrivateKey) Bytes() []byte {
+	return k
+}
+
+// IsPrivate always returns true.
+func (ExtendedPrivateKey) IsPrivate() bool {
+	return true
+}
+
+// Public returns the corresponding ExtendedPublicKey.
+func (k ExtendedPrivateKey) Public() slip10.Key {
+	return ExtendedPublicKey(k.Ed25519PublicKey())
+}
+
+// Wipe overwrites the key with zeros.
+func (k ExtendedPrivateKey) Wipe() {
+	wipe.Bytes(k)
+}
+
+// Clone returns a copy of the key.
+func (k ExtendedPrivateKey) Clone() slip10.Key {
+	return append(ExtendedPrivateKey{}, k...)
+}
+
+// Shift is not supported and always returns ErrShiftNotSupported.
+func (ExtendedPrivateKey) Shift([]byte) (slip10.Key, error) {
+	return nil, ErrShiftNotSupported
+}
+
+// DeriveChild implements the private child key derivation of BIP32-Ed25519.
+func (k ExtendedPrivateKey) DeriveChild(chainCode []byte, index uint32) (slip10.Key, []byte, error) {
+	var z, c []byte
+	if index >= slip10.Hardened {
+		// Z ← HMAC-SHA512(Key = c, Data = 0x00 || kL || kR || ser32LE(i))
+		z = hmacSHA512(chainCode, []byte{0x00}, k, uint32LEBytes(index))
+		// c ← HMAC-SHA512(Key = c, Data = 0x01 || kL || kR || ser32LE(i))
+		c = hmacSHA512(chainCode, []byte{0x01}, k, uint32LEBytes(index))
+	} else {
+		public := k.Ed25519PublicKey()
+		// Z ← HMAC-SHA512(Key = c, Data = 0x02 || A || ser32LE(i))
+		z = hmacSHA512(chainCode, []byte{0x02}, public, uint32LEBytes(index))
+		// c ← HMAC-SHA512(Key = c, Data = 0x03 || A || ser32LE(i))
+		c = hmacSHA512(chainCode, []byte{0x03}, public, uint32LEBytes(index))
+	}
+
+	child := make([]byte, ed25519.ExtendedPrivateKeySize)
+	// kL ← 8·ZL + kL, where ZL are the first 28 bytes of Z
+	add28Mul8(child[:32], k[:32], z[:28])
+	// kR ← ZR + kR mod 2²⁵⁶
+	add256(child[32:], k[32:], z[32:])
+
+	// Z and the unused left half of c must not remain in memory
+	wipe.Bytes(z)
+	wipe.Bytes(c[:32])
+
+	// if kL is divisible by the base order, the child key is invalid
+	if scalar(child[:32]).Equal(edwards25519.NewScalar()) == 1 {
+		wipe.Bytes(child)
+		return nil, nil, slip10.ErrInvalidKey
+	}
+	return ExtendedPrivateKey(child), c[32:], nil
+}
+
+// Ed25519PublicKey returns the corresponding Ed25519 public key.
+func (k ExtendedPrivateKey) Ed25519PublicKey() ed25519.PublicKey {
+	return ed25519.ExtendedPublicKey(k)
+}
+
+// Sign signs the message with the key and returns a signature that can be verified using ed25519.Verify.
+func (k ExtendedPrivateKey) Sign(message []byte) []byte {
+	return ed25519.SignExtended(k, message)
+}
+
+// ExtendedPublicKey implements slip10.Key and represents a BIP32-Ed25519 public key.
+// In contrast to PublicKey, it supports the derivation of public child keys.
+type ExtendedPublicKey ed25519.PublicKey
+
+// Bytes returns the SLIP-10 serialization of the key.
+func (p ExtendedPublicKey) Bytes() []byte {
+	return PublicKey(p).Bytes()
+}
+
+// IsPrivate always returns false.
+func (ExtendedPublicKey) IsPrivate() bool {
+	return false
+}
+
+// Public returns a reference to itself.
+func (p ExtendedPublicKey) Public() slip10.Key {
+	return p
+}
+
+// Shift is not supported and always returns ErrShiftNotSupported.
+func (ExtendedPublicKey) Shift([]byte) (slip10.Key, error) {
+	return nil, ErrShiftNotSupported
+}
+
+// DeriveChild implements the public child key derivation of BIP32-Ed25519.
+func (p ExtendedPublicKey) DeriveChild(chainCode []byte, index uint32) (slip10.Key, []byte, error) {
+	if index >= slip10.Hardened {
+		return nil, nil, slip10.ErrHardenedChildPublicKey
+	}
+	A, err := new(edwards25519.Point).SetBytes(p)
+	if err != nil {
+		return nil, nil, slip10.ErrInvalidKey
+	}
+
+	// Z ← HMAC-SHA512(Key = c, Data = 0x02 || A || ser32LE(i))
+	z := hmacSHA512(chainCode, []byte{0x02}, p, uint32LEBytes(index))
+	// c ← HMAC-SHA512(Key = c, Data = 0x03 || A || ser32LE(i))
+	c := hmacSHA512(chainCode, []byte{0x03}, p, uint32LEBytes(index))
+
+	// A ← A + [8·ZL]B
+	var zl8 [32]byte
+	add28Mul8(zl8[:], make([]byte, 32), z[:28])
+	A.Add(A, new(edwards25519.Point).ScalarBaseMult(scalar(zl8[:])))
+	if A.Equal(edwards25519.NewIdentityPoint()) == 1 {
+		return nil, nil, slip10.ErrInvalidKey
+	}
+	return ExtendedPublicKey(A.Bytes()), c[32:], nil
+}
+
+// Ed25519PublicKey returns the corresponding Ed25519 public key.
+func (p ExtendedPublicKey) Ed25519PublicKey() ed25519.PublicKey {
+	return ed25519.PublicKey(p)
+}
+
+// add28Mul8 computes dst = x + 8·y, where x is a 32-byte and y a 28-byte little-endian integer.
+func add28Mul8(dst, x, y []byte) {
+	var carry uint16
+	for i := 0; i < 28; i++ {
+		r := uint16(x[i]) + uint16(y[i])<<3 + carry
+		dst[i] = byte(r)
+		carry = r >> 8
+	}
+	for i := 28; i < 32; i++ {
+		r := uint16(x[i]) + carry
+		dst[i] = byte(r)
+		carry = r >> 8
+	}
+}
+
+// add256 computes dst = x + y mod 2²⁵⁶ for 32-byte little-endian integers.
+func add256(dst, x, y []byte) {
+	var carry uint16
+	for i := 0; i < 32; i++ {
+		r := uint16(x[i]) + uint16(y[i]) + carry
+		dst[i] = byte(r)
+		carry = r >> 8
+	}
+}
+
+// scalar returns the 32-byte little-endian integer b reduced modulo the base order.
+func scalar(b []byte) *edwards25519.Scalar {
+	var wide [64]byte
+	copy(wide[:], b)
+	s, err := edwards25519.NewScalar().SetUniformBytes(wide[:])
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func hmacSHA512(key []byte, data ...[]byte) []byte {
+	h := hmac.New(sha512.New, key)
+	for _, p := range data {
+		h.Write(p)
+	}
+	return h.Sum(nil)
+}
+
+func uint32LEBytes(i uint32) []byte {
+	bytes := make([]byte, 4)
+	binary.LittleEndian.PutUint32(bytes, i)
+	return bytes
+}
@@ -0,0 +1,59 @@
+package eddsa
+
+import (
+	"crypto/sha512"
+
+	"github.com/wollac/iota-crypto-demo/pkg/ed25519"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const icarusIterations = 4096
+
+type cardanoCurve struct {
+	bip32Ed25519Curve
+}
+
+// Cardano returns a slip10.Curve which implements the BIP32-Ed25519 master key generation described in SLIP-0023.
+// It must be used with slip10.NewMasterKey and the BIP-39 seed. All derived keys follow BIP32-Ed25519.
+// See https://github.com/satoshilabs/slips/blob/master/slip-0023.md.
+func Cardano() slip10.Curve {
+	return cardanoCurve{}
+}
+
+func (cardanoCurve) NewPrivateKey(buf []byte) (slip10.Key, error) {
+	if len(buf) != ed25519.SeedSize {
+		panic("invalid buffer length")
+	}
+
+	// k = SHA-512(I_L)
+	k := sha512.Sum512(buf)
+	clamp(k[:32])
+	return ExtendedPrivateKey(k[:]), nil
+}
+
+func (cardanoCurve) Name() string {
+	return "ed25519-cardano"
+}
+
+func (cardanoCurve) HmacKey() []byte {
+	return []byte("ed25519 cardano seed")
+}
+
+// NewIcarusMasterKey creates a BIP32-Ed25519 master key from BIP-39 entropy as used by Icarus-style Cardano wallets.
+// The entropy corresponds to the output of bip39.MnemonicToEntropy and not to the BIP-39 seed.
+// See https://github.com/cardano-foundation/CIPs/blob/master/CIP-0003/Icarus.md.
+func NewIcarusMasterKey(entropy []byte, passphrase string) *slip10.ExtendedKey {
+	// the passphrase is used as the password and the entropy as the salt
+	data := pbkdf2.Key([]byte(passphrase), entropy, icarusIterations, ed25519.ExtendedPrivateKeySize+slip10.ChainCodeSize, sha512.New)
+	clamp(data[:32])
+	return slip10.NewExtendedKey(ExtendedPrivateKey(data[:ed25519.ExtendedPrivateKeySize]), data[ed25519.ExtendedPrivateKeySize:], bip32Ed25519Curve{})
+}
+
+// clamp clears the lowest three bits and the highest three bits of kL before setting the second highest bit.
+// In contrast to the original BIP32-Ed25519, the third highest bit is cleared instead of rejecting the key.
+func clamp(kL []byte) {
+	kL[0] &= 0xf8
+	kL[31] &= 0x1f
+	kL[31] |= 0x40
+}
@@ -3,6 +3,8 @@ package eddsa
 import (
 	"errors"
 
+	"filippo.io/edwards25519"
+	"github.com/wollac/iota-crypto-demo/internal/wipe"
 	"github.com/wollac/iota-crypto-demo/pkg/ed25519"
 	"github.com/wollac/iota-crypto-demo/pkg/slip10"
 )
@@ -28,6 +30,20 @@ func (ed25519Curve) NewPrivateKey(buf []byte) (slip10.Key, error) {
 	return Seed(seed), nil
 }
 
+// NewPublicKey creates a PublicKey from its SLIP-10 serialization, i.e. the 32-byte key prefixed with 0x00.
+func (ed25519Curve) NewPublicKey(buf []byte) (slip10.Key, error) {
+	if len(buf) != slip10.PublicKeySize || buf[0] != 0x00 {
+		return nil, slip10.ErrInvalidKey
+	}
+	if _, err := new(edwards25519.Point).SetBytes(buf[1:]); err != nil {
+		return nil, slip10.ErrInvalidKey
+	}
+
+	publicKey := make([]byte, ed25519.PublicKeySize)
+	copy(publicKey, buf[1:])
+	return PublicKey(publicKey), nil
+}
+
 func (ed25519Curve) Name() string {
 	return "ed25519"
 }
@@ -56,6 +72,16 @@ func (s Seed) Public() slip10.Key {
 	return PublicKey(priv.Public().(ed25519.PublicKey))
 }
 
+// Wipe overwrites the seed with zeros.
+func (s Seed) Wipe() {
+	wipe.Bytes(s)
+}
+
+// Clone returns a copy of the seed.
+func (s Seed) Clone() slip10.Key {
+	return append(Seed{}, s...)
+}
+
 // Shift derives a new Seed from the provided bytes.
 func (Seed) Shift(buf []byte) (slip10.Key, error) {
 	if len(buf) != ed25519.SeedSize {
@@ -2,10 +2,37 @@ package elliptic
 
 import (
 	"crypto/elliptic"
+	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/wollac/iota-crypto-demo/pkg/slip10"
-	"github.com/wollac/iota-crypto-demo/pkg/slip10/elliptic/internal/btccurve"
+	secp "github.com/wollac/iota-crypto-demo/pkg/slip10/elliptic/internal/secp256k1"
+)
+
+var (
+	// ErrUnsupportedCurve is returned when an operation is not supported for the given curve.
+	ErrUnsupportedCurve = errors.New("unsupported curve")
+	// ErrInvalidPublicKey is returned when a public key cannot be parsed.
+	ErrInvalidPublicKey = errors.New("invalid public key")
+)
+
+// publicKeyError describes why a public key is invalid. It matches both ErrInvalidPublicKey and slip10.ErrInvalidKey.
+type publicKeyError string
+
+func (e publicKeyError) Error() string {
+	return string(e)
+}
+
+func (publicKeyError) Is(target error) bool {
+	return target == ErrInvalidPublicKey || target == slip10.ErrInvalidKey
+}
+
+// Errors returned when parsing public keys.
+var (
+	ErrInvalidEncoding error = publicKeyError("invalid public key encoding")
+	ErrNotOnCurve      error = publicKeyError("point is not on the curve")
+	ErrIdentity        error = publicKeyError("point at infinity is not a valid public key")
 )
 
 // Curve is an abstract implementation of slip10.Curve based on elliptic.Curve.
@@ -33,6 +60,77 @@ func (c Curve) NewPrivateKey(buf []byte) (slip10.Key, error) {
 	return &PrivateKey{sc, c}, nil
 }
 
+// NewPublicKey creates a PublicKey from buf in the compressed or uncompressed SEC1 encoding.
+// When buf does not correspond to a valid public key, an error wrapping slip10.ErrInvalidKey is returned.
+func (c Curve) NewPublicKey(buf []byte) (slip10.Key, error) {
+	return c.ParsePublicKey(buf)
+}
+
+// ParsePublicKey parses buf as a public key in the compressed (33 bytes) or uncompressed (65 bytes) SEC1 encoding.
+// It validates that the point lies on the curve and is not the point at infinity.
+func (c Curve) ParsePublicKey(buf []byte) (*PublicKey, error) {
+	byteLen := (c.Params().BitSize + 7) / 8
+	if len(buf) == 1 && buf[0] == 0x00 {
+		return nil, ErrIdentity
+	}
+
+	var x, y *big.Int
+	switch {
+	case len(buf) == 1+byteLen && (buf[0] == 0x02 || buf[0] == 0x03):
+		x = new(big.Int).SetBytes(buf[1:])
+		if x.Cmp(c.Params().P) >= 0 {
+			return nil, ErrInvalidEncoding
+		}
+		if x, y = unmarshalCompressed(c.Curve, buf); x == nil {
+			return nil, ErrNotOnCurve
+		}
+	case len(buf) == 1+2*byteLen && buf[0] == 0x04:
+		x = new(big.Int).SetBytes(buf[1 : 1+byteLen])
+		y = new(big.Int).SetBytes(buf[1+byteLen:])
+		if x.Sign() == 0 && y.Sign() == 0 {
+			return nil, ErrIdentity
+		}
+		if x.Cmp(c.Params().P) >= 0 || y.Cmp(c.Params().P) >= 0 {
+			return nil, ErrInvalidEncoding
+		}
+		if !c.IsOnCurve(x, y) {
+			return nil, ErrNotOnCurve
+		}
+	default:
+		return nil, ErrInvalidEncoding
+	}
+	return &PublicKey{x, y, c.Curve}, nil
+}
+
+// ParsePublicKey parses buf as a public key of the given curve in the compressed or uncompressed SEC1 encoding.
+// The curve must be either Secp256k1() or Nist256p1().
+func ParsePublicKey(curve slip10.Curve, buf []byte) (*PublicKey, error) {
+	parser, ok := curve.(interface {
+		ParsePublicKey(buf []byte) (*PublicKey, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurve, curve.Name())
+	}
+	return parser.ParsePublicKey(buf)
+}
+
+// unmarshalCompressed converts a point in the compressed SEC1 encoding into an x, y pair. On error, x = nil.
+func unmarshalCompressed(curve elliptic.Curve, buf []byte) (x, y *big.Int) {
+	// private keys reference the wrapping Curve
+	if c, ok := curve.(Curve); ok {
+		curve = c.Curve
+	}
+	if u, ok := curve.(compressedUnmarshaler); ok {
+		return u.UnmarshalCompressed(buf)
+	}
+	return elliptic.UnmarshalCompressed(curve, buf)
+}
+
+// compressedUnmarshaler is implemented by curves that provide their own point decompression.
+type compressedUnmarshaler interface {
+	UnmarshalCompressed(data []byte) (x, y *big.Int)
+}
+
 type secp256k1Curve struct {
 	Curve
 }
@@ -49,10 +147,17 @@ func (nist256p1Curve) HmacKey() []byte {
 	return []byte("Nist256p1 seed")
 }
 
-var secp256k1 = &secp256k1Curve{Curve{btccurve.Secp256k1()}}
+var secp256k1 = &secp256k1Curve{Curve{secp.Secp256k1()}}
 var nist256p1 = &nist256p1Curve{Curve{elliptic.P256()}}
 
+func init() {
+	// the BIP-32 versions refer to secp256k1 keys
+	slip10.RegisterVersions(slip10.Mainnet, secp256k1)
+	slip10.RegisterVersions(slip10.Testnet, secp256k1)
+}
+
 // Secp256k1 returns a slip10.Curve which implements secp256k1 (SEC 2, section 2.4.1).
+// Its point multiplications are constant time, the scalar arithmetic of the keys and signatures is not.
 func Secp256k1() slip10.Curve {
 	return secp256k1
 }
@@ -0,0 +1,40 @@
+package elliptic
+
+import (
+	"errors"
+
+	"github.com/wollac/iota-crypto-demo/internal/wipe"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/internal/session"
+)
+
+// ErrCurveMismatch is returned when the keys of a key agreement belong to different curves.
+var ErrCurveMismatch = errors.New("keys belong to different curves")
+
+// SharedSecret computes the ECDH shared secret between the private key and the peer's public key as defined in
+// SEC 1, section 3.3.1, i.e. the 32-byte x-coordinate of the product of the private scalar and the peer's point.
+// The shared secret must not be used as a key directly, instead a KDF like in SessionKey should be applied.
+func (p *PrivateKey) SharedSecret(peer *PublicKey) ([]byte, error) {
+	if peer.Curve.Params() != p.Curve.Params() {
+		return nil, ErrCurveMismatch
+	}
+	if !peer.Curve.IsOnCurve(peer.X, peer.Y) {
+		return nil, ErrNotOnCurve
+	}
+
+	k := scalarBytes(p.K)
+	defer wipe.Bytes(k)
+	x, _ := p.Curve.ScalarMult(peer.X, peer.Y, k)
+	// as both supported curves have a prime order, the product of a valid point can never be the point at infinity
+	return scalarBytes(x), nil
+}
+
+// SessionKey derives a symmetric session key of the given size from the ECDH shared secret with the peer.
+// The key is derived using HKDF-SHA256 (RFC 5869) with the optional salt and the application specific info.
+func (p *PrivateKey) SessionKey(peer *PublicKey, salt, info []byte, size int) ([]byte, error) {
+	secret, err := p.SharedSecret(peer)
+	if err != nil {
+		return nil, err
+	}
+	defer wipe.Bytes(secret)
+	return session.Key(secret, salt, info, size)
+}
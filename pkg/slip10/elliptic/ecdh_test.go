@@ -0,0 +1,88 @@
+package elliptic_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+func TestSharedSecret(t *testing.T) {
+	var tests = []*struct {
+		name   string
+		curve  string
+		key    []byte
+		peer   []byte
+		secret []byte
+	}{
+		{
+			// NIST CAVS 14.1, KAS ECC CDH primitive, P-256, COUNT = 0
+			"nist", "nist256p1",
+			decode("7d7dc5f71eb29ddaf80d6214632eeae03d9058af1fb6d22ed80badb62bc1a534"),
+			decode("04700c48f77f56584c5cc632ca65640db91b6bacce3a4df6b42ce7cc838833d287db71e509e3fd9b060ddb20ba5c51dcc5948d46fbf640dfe0441782cab85fa4ac"),
+			decode("46fc62106420ff012e54a434fbdd2d25ccc5852060561e68040dd7778997bd7b"),
+		},
+		{
+			// key = SHA-256("alice"), peer = SHA-256("bob")⋅G
+			"secp256k1", "secp256k1",
+			decode("2bd806c97f0e00af1a1fc3328fa763a9269723c8db8fac4f93af71db186d6e90"),
+			decode("024edfcf9dfe6c0b5c83d1ab3f78d1b39a46ebac6798e08e19761f5ed89ec83c10"),
+			decode("05aaea3882116920f603246a563cc2f3da5704bdf9d33ca60a29298956c26cf9"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			curve := curves[tt.curve]
+			k, err := curve.NewPrivateKey(tt.key)
+			require.NoError(t, err)
+			peer, err := elliptic.ParsePublicKey(curve, tt.peer)
+			require.NoError(t, err)
+
+			secret, err := k.(*elliptic.PrivateKey).SharedSecret(peer)
+			require.NoError(t, err)
+			assert.Equal(t, tt.secret, secret)
+		})
+	}
+}
+
+func TestSessionKey(t *testing.T) {
+	for name, curve := range curves {
+		t.Run(name, func(t *testing.T) {
+			a, err := curve.NewPrivateKey(bytes.Repeat([]byte{0x01}, 32))
+			require.NoError(t, err)
+			b, err := curve.NewPrivateKey(bytes.Repeat([]byte{0x02}, 32))
+			require.NoError(t, err)
+			alice, bob := a.(*elliptic.PrivateKey), b.(*elliptic.PrivateKey)
+
+			key1, err := alice.SessionKey(bob.Public().(*elliptic.PublicKey), []byte("salt"), []byte("info"), 32)
+			require.NoError(t, err)
+			key2, err := bob.SessionKey(alice.Public().(*elliptic.PublicKey), []byte("salt"), []byte("info"), 32)
+			require.NoError(t, err)
+			assert.Equal(t, key1, key2)
+
+			// different info must lead to a different key
+			key3, err := bob.SessionKey(alice.Public().(*elliptic.PublicKey), []byte("salt"), []byte("other"), 32)
+			require.NoError(t, err)
+			assert.NotEqual(t, key1, key3)
+		})
+	}
+}
+
+func TestSharedSecretInvalid(t *testing.T) {
+	k1, err := elliptic.Secp256k1().NewPrivateKey(bytes.Repeat([]byte{0x01}, 32))
+	require.NoError(t, err)
+	k2, err := elliptic.Nist256p1().NewPrivateKey(bytes.Repeat([]byte{0x01}, 32))
+	require.NoError(t, err)
+	key := k1.(*elliptic.PrivateKey)
+
+	_, err = key.SharedSecret(k2.Public().(*elliptic.PublicKey))
+	assert.ErrorIs(t, err, elliptic.ErrCurveMismatch)
+
+	public := *key.Public().(*elliptic.PublicKey)
+	public.Y = new(big.Int).Add(public.Y, big.NewInt(1))
+	_, err = key.SharedSecret(&public)
+	assert.ErrorIs(t, err, elliptic.ErrNotOnCurve)
+}
@@ -0,0 +1,257 @@
+package elliptic
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"github.com/wollac/iota-crypto-demo/internal/wipe"
+)
+
+const (
+	// HashSize is the size, in bytes, of the message hashes that can be signed.
+	HashSize = 32
+	// SignatureSize is the size, in bytes, of recoverable signatures.
+	SignatureSize = 65
+)
+
+// Errors returned when signing or recovering.
+var (
+	ErrInvalidHash      = errors.New("invalid hash length")
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+// Sign signs the message hash with the private key and returns a recoverable signature in the form r || s || v,
+// where v is the recovery ID in the range [0, 3].
+// The nonce is derived deterministically as described in RFC 6979 using HMAC-SHA256, and the signature is normalized
+// to the lower of the two possible S values to prevent malleability.
+func (p *PrivateKey) Sign(hash []byte) ([]byte, error) {
+	if len(hash) != HashSize {
+		return nil, ErrInvalidHash
+	}
+	params := p.Curve.Params()
+	e := hashToInt(hash, params.N)
+
+	g := newNonceGenerator(p.K, e)
+	defer g.wipe()
+
+	k := new(big.Int)
+	defer wipeInt(k)
+	for {
+		buf := g.next()
+		k.SetBytes(buf)
+		if k.Sign() == 0 || k.Cmp(params.N) >= 0 {
+			continue
+		}
+
+		x, y := p.Curve.ScalarBaseMult(buf)
+		r := new(big.Int).Mod(x, params.N)
+		if r.Sign() == 0 {
+			continue
+		}
+		// s = k⁻¹(e + r⋅d) mod N
+		s := new(big.Int).Mul(r, p.K)
+		s.Add(s, e)
+		s.Mul(s, k.ModInverse(k, params.N))
+		s.Mod(s, params.N)
+		if s.Sign() == 0 {
+			continue
+		}
+
+		v := byte(y.Bit(0))
+		if x.Cmp(params.N) >= 0 {
+			v |= 2
+		}
+		// negating s corresponds to negating the point R
+		if s.Cmp(halfOrder(params)) > 0 {
+			s.Sub(params.N, s)
+			v ^= 1
+		}
+
+		sig := make([]byte, SignatureSize)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:64])
+		sig[64] = v
+		return sig, nil
+	}
+}
+
+// Verify reports whether sig is a valid signature of hash by the public key.
+// The signature can either be a 64-byte signature r || s or a recoverable signature as returned by PrivateKey.Sign.
+// Signatures with a high S value are rejected.
+func (p *PublicKey) Verify(hash, sig []byte) bool {
+	if len(hash) != HashSize || (len(sig) != SignatureSize && len(sig) != SignatureSize-1) {
+		return false
+	}
+	params := p.Curve.Params()
+	r, s, err := parseSignature(params, sig[:64])
+	if err != nil {
+		return false
+	}
+	e := hashToInt(hash, params.N)
+
+	// R = e⋅s⁻¹⋅G + r⋅s⁻¹⋅Q
+	w := new(big.Int).ModInverse(s, params.N)
+	u1 := e.Mul(e, w)
+	u1.Mod(u1, params.N)
+	u2 := w.Mul(r, w)
+	u2.Mod(u2, params.N)
+
+	x1, y1 := p.Curve.ScalarBaseMult(scalarBytes(u1))
+	x2, y2 := p.Curve.ScalarMult(p.X, p.Y, scalarBytes(u2))
+	x, y := p.Curve.Add(x1, y1, x2, y2)
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return false
+	}
+	return x.Mod(x, params.N).Cmp(r) == 0
+}
+
+// RecoverPublicKey recovers the secp256k1 public key from hash and the recoverable signature sig as returned by
+// PrivateKey.Sign.
+func RecoverPublicKey(hash, sig []byte) (*PublicKey, error) {
+	return RecoverPublicKeyFromCurve(secp256k1.Curve.Curve, hash, sig)
+}
+
+// RecoverPublicKeyFromCurve recovers the public key for the given curve from hash and the recoverable signature sig
+// as returned by PrivateKey.Sign.
+func RecoverPublicKeyFromCurve(curve elliptic.Curve, hash, sig []byte) (*PublicKey, error) {
+	if len(hash) != HashSize {
+		return nil, ErrInvalidHash
+	}
+	if len(sig) != SignatureSize {
+		return nil, ErrInvalidSignature
+	}
+	params := curve.Params()
+	r, s, err := parseSignature(params, sig[:64])
+	if err != nil {
+		return nil, err
+	}
+	v := sig[64]
+	if v > 3 {
+		return nil, ErrInvalidSignature
+	}
+
+	// reconstruct R from its x-coordinate and the parity of its y-coordinate
+	x := new(big.Int).Set(r)
+	if v&2 != 0 {
+		x.Add(x, params.N)
+	}
+	if x.Cmp(params.P) >= 0 {
+		return nil, ErrInvalidSignature
+	}
+	buf := make([]byte, 1+(params.BitSize+7)/8)
+	buf[0] = 2 | v&1
+	x.FillBytes(buf[1:])
+	rx, ry := unmarshalCompressed(curve, buf)
+	if rx == nil {
+		return nil, ErrInvalidSignature
+	}
+
+	// Q = r⁻¹(s⋅R - e⋅G)
+	rInv := new(big.Int).ModInverse(r, params.N)
+	u1 := hashToInt(hash, params.N)
+	u1.Neg(u1)
+	u1.Mul(u1, rInv)
+	u1.Mod(u1, params.N)
+	u2 := rInv.Mul(s, rInv)
+	u2.Mod(u2, params.N)
+
+	x1, y1 := curve.ScalarBaseMult(scalarBytes(u1))
+	x2, y2 := curve.ScalarMult(rx, ry, scalarBytes(u2))
+	qx, qy := curve.Add(x1, y1, x2, y2)
+	if qx.Sign() == 0 && qy.Sign() == 0 {
+		return nil, ErrInvalidSignature
+	}
+	return &PublicKey{qx, qy, curve}, nil
+}
+
+// parseSignature parses the 64-byte signature r || s and checks that both values are in range and s is low.
+func parseSignature(params *elliptic.CurveParams, sig []byte) (r, s *big.Int, err error) {
+	r = new(big.Int).SetBytes(sig[:32])
+	s = new(big.Int).SetBytes(sig[32:64])
+	if r.Sign() == 0 || r.Cmp(params.N) >= 0 || s.Sign() == 0 || s.Cmp(halfOrder(params)) > 0 {
+		return nil, nil, ErrInvalidSignature
+	}
+	return r, s, nil
+}
+
+// hashToInt converts hash to an integer modulo N as specified in SEC 1, section 4.1.3.
+func hashToInt(hash []byte, n *big.Int) *big.Int {
+	e := new(big.Int).SetBytes(hash)
+	if excess := len(hash)*8 - n.BitLen(); excess > 0 {
+		e.Rsh(e, uint(excess))
+	}
+	return e.Mod(e, n)
+}
+
+func halfOrder(params *elliptic.CurveParams) *big.Int {
+	return new(big.Int).Rsh(params.N, 1)
+}
+
+func scalarBytes(k *big.Int) []byte {
+	return k.FillBytes(make([]byte, 32))
+}
+
+func wipeInt(k *big.Int) {
+	words := k.Bits()
+	for i := range words {
+		words[i] = 0
+	}
+	k.SetInt64(0)
+}
+
+// nonceGenerator generates the nonce candidates of RFC 6979, section 3.2, for 256-bit curves using HMAC-SHA256.
+type nonceGenerator struct {
+	k, v  []byte
+	first bool
+}
+
+func newNonceGenerator(x, e *big.Int) *nonceGenerator {
+	g := &nonceGenerator{
+		k:     make([]byte, sha256.Size),
+		v:     make([]byte, sha256.Size),
+		first: true,
+	}
+	for i := range g.v {
+		g.v[i] = 0x01
+	}
+	// int2octets(x) || bits2octets(h)
+	data := make([]byte, 64)
+	defer wipe.Bytes(data)
+	x.FillBytes(data[:32])
+	e.FillBytes(data[32:])
+
+	g.mac(g.k, g.v, []byte{0x00}, data)
+	g.mac(g.v, g.v)
+	g.mac(g.k, g.v, []byte{0x01}, data)
+	g.mac(g.v, g.v)
+	return g
+}
+
+// next returns the next nonce candidate; the caller must check that it is in [1, N-1].
+func (g *nonceGenerator) next() []byte {
+	if !g.first {
+		g.mac(g.k, g.v, []byte{0x00})
+		g.mac(g.v, g.v)
+	}
+	g.first = false
+	// qlen equals the hash size, so that a single block is sufficient
+	g.mac(g.v, g.v)
+	return g.v
+}
+
+// mac computes HMAC-SHA256 of data with the current key K and stores the result in dst.
+func (g *nonceGenerator) mac(dst []byte, data ...[]byte) {
+	h := hmac.New(sha256.New, g.k)
+	for _, d := range data {
+		h.Write(d)
+	}
+	h.Sum(dst[:0])
+}
+
+func (g *nonceGenerator) wipe() {
+	wipe.Bytes(g.k)
+	wipe.Bytes(g.v)
+}
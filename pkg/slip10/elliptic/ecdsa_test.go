@@ -0,0 +1,106 @@
+package elliptic_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wollac/iota-crypto-demo/internal/hexutil"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+var curves = map[string]slip10.Curve{
+	"secp256k1": elliptic.Secp256k1(),
+	"nist256p1": elliptic.Nist256p1(),
+}
+
+func TestSign(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("testdata", t.Name()+".json"))
+	require.NoError(t, err)
+
+	var tvs []struct {
+		Curve     string        `json:"curve"`
+		Key       hexutil.Bytes `json:"key"`
+		Hash      hexutil.Bytes `json:"hash"`
+		Signature hexutil.Bytes `json:"signature"`
+	}
+	require.NoError(t, json.Unmarshal(b, &tvs))
+
+	for _, tv := range tvs {
+		t.Run(tv.Curve, func(t *testing.T) {
+			k, err := curves[tv.Curve].NewPrivateKey(tv.Key)
+			require.NoError(t, err)
+			key := k.(*elliptic.PrivateKey)
+			public := key.Public().(*elliptic.PublicKey)
+
+			sig, err := key.Sign(tv.Hash)
+			require.NoError(t, err)
+			assert.EqualValues(t, tv.Signature, sig)
+
+			assert.True(t, public.Verify(tv.Hash, sig))
+			assert.True(t, public.Verify(tv.Hash, sig[:64]))
+			// the signature must also be valid for the standard library
+			r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64])
+			assert.True(t, ecdsa.Verify(public.ECDSAPublicKey(), tv.Hash, r, s))
+
+			recovered, err := elliptic.RecoverPublicKeyFromCurve(key.Curve, tv.Hash, sig)
+			require.NoError(t, err)
+			assert.Equal(t, public.Bytes(), recovered.Bytes())
+		})
+	}
+}
+
+func TestRecoverPublicKey(t *testing.T) {
+	key, err := elliptic.Secp256k1().NewPrivateKey(sha256.New().Sum(nil))
+	require.NoError(t, err)
+	hash := sha256.Sum256([]byte("message"))
+
+	sig, err := key.(*elliptic.PrivateKey).Sign(hash[:])
+	require.NoError(t, err)
+	require.Len(t, sig, elliptic.SignatureSize)
+
+	recovered, err := elliptic.RecoverPublicKey(hash[:], sig)
+	require.NoError(t, err)
+	assert.Equal(t, key.Public().Bytes(), recovered.Bytes())
+
+	// a different recovery ID results in a different key
+	sig[64] ^= 1
+	recovered, err = elliptic.RecoverPublicKey(hash[:], sig)
+	require.NoError(t, err)
+	assert.NotEqual(t, key.Public().Bytes(), recovered.Bytes())
+	sig[64] ^= 1
+
+	_, err = elliptic.RecoverPublicKey(hash[:16], sig)
+	assert.ErrorIs(t, err, elliptic.ErrInvalidHash)
+	_, err = elliptic.RecoverPublicKey(hash[:], sig[:64])
+	assert.ErrorIs(t, err, elliptic.ErrInvalidSignature)
+	_, err = elliptic.RecoverPublicKey(hash[:], append(sig[:64:64], 4))
+	assert.ErrorIs(t, err, elliptic.ErrInvalidSignature)
+}
+
+func TestVerifyHighS(t *testing.T) {
+	k, err := elliptic.Nist256p1().NewPrivateKey(sha256.New().Sum(nil))
+	require.NoError(t, err)
+	key := k.(*elliptic.PrivateKey)
+	public := key.Public().(*elliptic.PublicKey)
+	hash := sha256.Sum256([]byte("message"))
+
+	sig, err := key.Sign(hash[:])
+	require.NoError(t, err)
+	require.True(t, public.Verify(hash[:], sig))
+
+	// replace s with N - s
+	n := key.Curve.Params().N
+	s := new(big.Int).SetBytes(sig[32:64])
+	s.Sub(n, s).FillBytes(sig[32:64])
+	assert.False(t, public.Verify(hash[:], sig))
+	_, err = elliptic.RecoverPublicKeyFromCurve(key.Curve, hash[:], sig)
+	assert.ErrorIs(t, err, elliptic.ErrInvalidSignature)
+}
@@ -5,6 +5,8 @@
 // license that can be found in the LICENSE file.
 
 // Package btccurve implements the secp256k1 curve used by Bitcoin.
+// The implementation is based on math/big and is neither fast nor constant time; it only serves as a reference for
+// the secp256k1 package.
 package btccurve
 
 import (
@@ -53,6 +55,9 @@ func (curve koblitzCurve) IsOnCurve(x, y *big.Int) bool {
 
 // affineFromJacobian reverses the Jacobian transform.
 func (curve koblitzCurve) affineFromJacobian(x, y, z *big.Int) (xOut, yOut *big.Int) {
+	if z.Sign() == 0 {
+		return new(big.Int), new(big.Int)
+	}
 	zinv := new(big.Int).ModInverse(z, curve.P)
 	zinvsq := new(big.Int).Mul(zinv, zinv)
 
@@ -66,6 +71,20 @@ func (curve koblitzCurve) affineFromJacobian(x, y, z *big.Int) (xOut, yOut *big.
 }
 
 func (curve koblitzCurve) Add(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
+	// the point at infinity is represented as (0, 0)
+	if x1.Sign() == 0 && y1.Sign() == 0 {
+		return new(big.Int).Set(x2), new(big.Int).Set(y2)
+	}
+	if x2.Sign() == 0 && y2.Sign() == 0 {
+		return new(big.Int).Set(x1), new(big.Int).Set(y1)
+	}
+	// the addition formula is not defined for P + P and P + (-P)
+	if x1.Cmp(x2) == 0 {
+		if y1.Cmp(y2) == 0 {
+			return curve.Double(x1, y1)
+		}
+		return new(big.Int), new(big.Int)
+	}
 	z := new(big.Int).SetInt64(1)
 	return curve.affineFromJacobian(curve.addJacobian(x1, y1, z, x2, y2, z))
 }
@@ -180,8 +199,8 @@ func (curve koblitzCurve) ScalarMult(Bx, By *big.Int, k []byte) (*big.Int, *big.
 	// machine. Thus the standard add/double algorithm has to be tweaked
 	// slightly: our initial state is not the identity, but x, and we
 	// ignore the first true bit in |k|.  If we don't find any true bits in
-	// |k|, then we return nil, nil, because we cannot return the identity
-	// element.
+	// |k|, then we return (0, 0), the conventional representation of the
+	// identity element.
 
 	Bz := new(big.Int).SetInt64(1)
 	x := Bx
@@ -206,7 +225,7 @@ func (curve koblitzCurve) ScalarMult(Bx, By *big.Int, k []byte) (*big.Int, *big.
 	}
 
 	if !seenFirstTrue {
-		return nil, nil
+		return new(big.Int), new(big.Int)
 	}
 
 	return curve.affineFromJacobian(x, y, z)
@@ -219,3 +238,36 @@ func (curve koblitzCurve) ScalarBaseMult(k []byte) (*big.Int, *big.Int) {
 func (curve koblitzCurve) Params() *elliptic.CurveParams {
 	return curve.CurveParams
 }
+
+// UnmarshalCompressed converts a point, serialized by elliptic.MarshalCompressed, into an x, y pair.
+// It is an error if the point is not in compressed form or is not on the curve. On error, x = nil.
+func (curve koblitzCurve) UnmarshalCompressed(data []byte) (x, y *big.Int) {
+	byteLen := (curve.BitSize + 7) / 8
+	if len(data) != 1+byteLen {
+		return nil, nil
+	}
+	if data[0] != 2 && data[0] != 3 { // compressed form
+		return nil, nil
+	}
+	x = new(big.Int).SetBytes(data[1:])
+	if x.Cmp(curve.P) >= 0 {
+		return nil, nil
+	}
+	// y² = x³ + b
+	y = new(big.Int).Mul(x, x)
+	y.Mul(y, x)
+	y.Add(y, curve.B)
+	y.Mod(y, curve.P)
+	// if x is not the x-coordinate of any point, there is no square root
+	y = y.ModSqrt(y, curve.P)
+	if y == nil {
+		return nil, nil
+	}
+	if byte(y.Bit(0)) != data[0]&1 {
+		y.Neg(y).Mod(y, curve.P)
+	}
+	if !curve.IsOnCurve(x, y) {
+		return nil, nil
+	}
+	return
+}
@@ -0,0 +1,227 @@
+package secp256k1
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// fieldElement is an element of the field GF(p) with p = 2²⁵⁶ - 2³² - 977.
+// It is stored in Montgomery form, i.e. as a⋅R mod p with R = 2²⁵⁶, using little-endian 64-bit limbs.
+// All operations are constant time.
+type fieldElement [4]uint64
+
+var (
+	// fieldP is the field prime p.
+	fieldP = [4]uint64{0xfffffffefffffc2f, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff}
+	// fieldPInv is -p⁻¹ mod 2⁶⁴.
+	fieldPInv uint64 = 0xd838091dd2253531
+	// fieldR2 is R² mod p, used to convert into the Montgomery form.
+	fieldR2 = fieldElement{0x000007a2000e90a1, 0x0000000000000001, 0, 0}
+	// fieldOne is 1 in Montgomery form.
+	fieldOne = fieldElement{0x00000001000003d1, 0, 0, 0}
+	// fieldB3 is 3⋅b = 21 in Montgomery form.
+	fieldB3 = fieldElement{0x0000001500005025, 0, 0, 0}
+)
+
+// setBytes sets e to the big-endian value b and reports whether b is smaller than p.
+// If b is not canonical, e is not modified.
+func (e *fieldElement) setBytes(b *[32]byte) bool {
+	var a [4]uint64
+	for i := range a {
+		a[i] = binary.BigEndian.Uint64(b[24-8*i:])
+	}
+	// a is canonical iff a - p borrows
+	var borrow uint64
+	for i := range a {
+		_, borrow = bits.Sub64(a[i], fieldP[i], borrow)
+	}
+	if borrow == 0 {
+		return false
+	}
+	e.mul((*fieldElement)(&a), &fieldR2)
+	return true
+}
+
+// bytes stores the canonical big-endian encoding of e in b.
+func (e *fieldElement) bytes(b *[32]byte) {
+	var a fieldElement
+	a.mul(e, &fieldElement{1})
+	for i := range a {
+		binary.BigEndian.PutUint64(b[24-8*i:], a[i])
+	}
+}
+
+// set sets e = a.
+func (e *fieldElement) set(a *fieldElement) *fieldElement {
+	*e = *a
+	return e
+}
+
+// add sets e = a + b mod p.
+func (e *fieldElement) add(a, b *fieldElement) *fieldElement {
+	var t [4]uint64
+	var carry uint64
+	for i := range t {
+		t[i], carry = bits.Add64(a[i], b[i], carry)
+	}
+	e.reduce(&t, carry)
+	return e
+}
+
+// sub sets e = a - b mod p.
+func (e *fieldElement) sub(a, b *fieldElement) *fieldElement {
+	var t [4]uint64
+	var borrow uint64
+	for i := range t {
+		t[i], borrow = bits.Sub64(a[i], b[i], borrow)
+	}
+	// add p back, if the subtraction borrowed
+	mask := -borrow
+	var carry uint64
+	for i := range t {
+		e[i], carry = bits.Add64(t[i], fieldP[i]&mask, carry)
+	}
+	return e
+}
+
+// neg sets e = -a mod p.
+func (e *fieldElement) neg(a *fieldElement) *fieldElement {
+	return e.sub(&fieldElement{}, a)
+}
+
+// mul sets e = a⋅b⋅R⁻¹ mod p using the CIOS Montgomery multiplication.
+func (e *fieldElement) mul(a, b *fieldElement) *fieldElement {
+	var t [6]uint64
+	for i := 0; i < 4; i++ {
+		// t += a⋅b[i]
+		var c uint64
+		for j := 0; j < 4; j++ {
+			hi, lo := bits.Mul64(a[j], b[i])
+			var cc uint64
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j], c = lo, hi
+		}
+		var cc uint64
+		t[4], cc = bits.Add64(t[4], c, 0)
+		t[5] = cc
+
+		// t = (t + u⋅p) / 2⁶⁴
+		u := t[0] * fieldPInv
+		hi, lo := bits.Mul64(u, fieldP[0])
+		_, cc = bits.Add64(lo, t[0], 0)
+		c = hi + cc
+		for j := 1; j < 4; j++ {
+			hi, lo = bits.Mul64(u, fieldP[j])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j-1], c = lo, hi
+		}
+		t[3], cc = bits.Add64(t[4], c, 0)
+		t[4] = t[5] + cc
+	}
+	e.reduce((*[4]uint64)(t[:4]), t[4])
+	return e
+}
+
+// square sets e = a² in Montgomery form.
+func (e *fieldElement) square(a *fieldElement) *fieldElement {
+	return e.mul(a, a)
+}
+
+// reduce sets e = t + carry⋅2²⁵⁶ mod p for a value smaller than 2p.
+func (e *fieldElement) reduce(t *[4]uint64, carry uint64) {
+	var s [4]uint64
+	var borrow uint64
+	for i := range s {
+		s[i], borrow = bits.Sub64(t[i], fieldP[i], borrow)
+	}
+	// use t - p, if t + carry⋅2²⁵⁶ ≥ p
+	mask := -(carry | (borrow ^ 1))
+	for i := range e {
+		e[i] = s[i]&mask | t[i]&^mask
+	}
+}
+
+// pow sets e = a^k for the big-endian exponent k.
+// The exponent is considered public, i.e. the execution time depends on k.
+func (e *fieldElement) pow(a *fieldElement, k *[32]byte) *fieldElement {
+	x := *a
+	r := fieldOne
+	for _, b := range k {
+		for bit := 7; bit >= 0; bit-- {
+			r.square(&r)
+			if b>>bit&1 == 1 {
+				r.mul(&r, &x)
+			}
+		}
+	}
+	*e = r
+	return e
+}
+
+var (
+	// pMinus2 is the exponent p-2 used for inversions.
+	pMinus2 = [32]byte{
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfe, 0xff, 0xff, 0xfc, 0x2d,
+	}
+	// pPlus1Div4 is the exponent (p+1)/4 used for square roots.
+	pPlus1Div4 = [32]byte{
+		0x3f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xbf, 0xff, 0xff, 0x0c,
+	}
+)
+
+// invert sets e = a⁻¹ mod p using Fermat's little theorem. The inverse of 0 is 0.
+func (e *fieldElement) invert(a *fieldElement) *fieldElement {
+	return e.pow(a, &pMinus2)
+}
+
+// sqrt sets e to a square root of a and reports whether a is a square.
+func (e *fieldElement) sqrt(a *fieldElement) bool {
+	// as p ≡ 3 mod 4, a^((p+1)/4) is a square root of a if one exists
+	var r, r2 fieldElement
+	r.pow(a, &pPlus1Div4)
+	if r2.square(&r).equal(a) != 1 {
+		return false
+	}
+	*e = r
+	return true
+}
+
+// isOdd returns 1 if the canonical value of e is odd, and 0 otherwise.
+func (e *fieldElement) isOdd() int {
+	var a fieldElement
+	a.mul(e, &fieldElement{1})
+	return int(a[0] & 1)
+}
+
+// isZero returns 1 if e = 0, and 0 otherwise.
+func (e *fieldElement) isZero() int {
+	// the Montgomery form of 0 is 0
+	acc := e[0] | e[1] | e[2] | e[3]
+	return int(1 ^ (acc|-acc)>>63)
+}
+
+// equal returns 1 if e = a, and 0 otherwise.
+func (e *fieldElement) equal(a *fieldElement) int {
+	var d fieldElement
+	for i := range d {
+		d[i] = e[i] ^ a[i]
+	}
+	return d.isZero()
+}
+
+// selectFrom sets e = a if cond = 1, and e = b if cond = 0.
+func (e *fieldElement) selectFrom(a, b *fieldElement, cond int) *fieldElement {
+	mask := -uint64(cond)
+	for i := range e {
+		e[i] = a[i]&mask | b[i]&^mask
+	}
+	return e
+}
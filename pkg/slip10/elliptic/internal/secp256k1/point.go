@@ -0,0 +1,198 @@
+package secp256k1
+
+import (
+	"sync"
+)
+
+// point is a point on the curve in homogeneous projective coordinates (X:Y:Z) with x = X/Z and y = Y/Z.
+// The point at infinity is represented as (0:1:0).
+type point struct {
+	x, y, z fieldElement
+}
+
+// setInfinity sets p to the point at infinity.
+func (p *point) setInfinity() *point {
+	p.x = fieldElement{}
+	p.y = fieldOne
+	p.z = fieldElement{}
+	return p
+}
+
+// setAffine sets p to the affine point (x, y).
+func (p *point) setAffine(x, y *fieldElement) *point {
+	p.x.set(x)
+	p.y.set(y)
+	p.z = fieldOne
+	return p
+}
+
+// affine returns the affine coordinates of p. For the point at infinity, (0, 0) is returned.
+func (p *point) affine() (x, y fieldElement) {
+	var zInv fieldElement
+	// the inverse of 0 is 0, so that the point at infinity maps to (0, 0)
+	zInv.invert(&p.z)
+	x.mul(&p.x, &zInv)
+	y.mul(&p.y, &zInv)
+	return
+}
+
+// add sets p = a + b using the complete addition formula for a = 0 of Renes, Costello and Batina (Algorithm 7 of
+// https://eprint.iacr.org/2015/1060). It handles all inputs, including the point at infinity, in constant time.
+func (p *point) add(a, b *point) *point {
+	var t0, t1, t2, t3, t4, x3, y3, z3 fieldElement
+	t0.mul(&a.x, &b.x)
+	t1.mul(&a.y, &b.y)
+	t2.mul(&a.z, &b.z)
+	t3.add(&a.x, &a.y)
+	t4.add(&b.x, &b.y)
+	t3.mul(&t3, &t4)
+	t4.add(&t0, &t1)
+	t3.sub(&t3, &t4)
+	t4.add(&a.y, &a.z)
+	x3.add(&b.y, &b.z)
+	t4.mul(&t4, &x3)
+	x3.add(&t1, &t2)
+	t4.sub(&t4, &x3)
+	x3.add(&a.x, &a.z)
+	y3.add(&b.x, &b.z)
+	x3.mul(&x3, &y3)
+	y3.add(&t0, &t2)
+	y3.sub(&x3, &y3)
+	x3.add(&t0, &t0)
+	t0.add(&x3, &t0)
+	t2.mul(&fieldB3, &t2)
+	z3.add(&t1, &t2)
+	t1.sub(&t1, &t2)
+	y3.mul(&fieldB3, &y3)
+	x3.mul(&t4, &y3)
+	t2.mul(&t3, &t1)
+	x3.sub(&t2, &x3)
+	y3.mul(&y3, &t0)
+	t1.mul(&t1, &z3)
+	y3.add(&t1, &y3)
+	t0.mul(&t0, &t3)
+	z3.mul(&z3, &t4)
+	z3.add(&z3, &t0)
+
+	p.x, p.y, p.z = x3, y3, z3
+	return p
+}
+
+// double sets p = 2⋅a using the doubling formula for a = 0 of Renes, Costello and Batina (Algorithm 9 of
+// https://eprint.iacr.org/2015/1060).
+func (p *point) double(a *point) *point {
+	var t0, t1, t2, x3, y3, z3 fieldElement
+	t0.square(&a.y)
+	z3.add(&t0, &t0)
+	z3.add(&z3, &z3)
+	z3.add(&z3, &z3)
+	t1.mul(&a.y, &a.z)
+	t2.square(&a.z)
+	t2.mul(&fieldB3, &t2)
+	x3.mul(&t2, &z3)
+	y3.add(&t0, &t2)
+	z3.mul(&t1, &z3)
+	t1.add(&t2, &t2)
+	t2.add(&t1, &t2)
+	t0.sub(&t0, &t2)
+	y3.mul(&t0, &y3)
+	y3.add(&x3, &y3)
+	t1.mul(&a.x, &a.y)
+	x3.mul(&t0, &t1)
+	x3.add(&x3, &x3)
+
+	p.x, p.y, p.z = x3, y3, z3
+	return p
+}
+
+// selectFrom sets p = a if cond = 1, and p = b if cond = 0.
+func (p *point) selectFrom(a, b *point, cond int) *point {
+	p.x.selectFrom(&a.x, &b.x, cond)
+	p.y.selectFrom(&a.y, &b.y, cond)
+	p.z.selectFrom(&a.z, &b.z, cond)
+	return p
+}
+
+// table contains the multiples 0⋅P, 1⋅P, …, 15⋅P of a point P.
+type table [16]point
+
+// init computes the multiples of q.
+func (t *table) init(q *point) {
+	t[0].setInfinity()
+	t[1] = *q
+	for i := 2; i < len(t); i++ {
+		t[i].add(&t[i-1], q)
+	}
+}
+
+// lookup sets p = i⋅P in constant time, i.e. without depending on i in the memory access pattern.
+func (t *table) lookup(p *point, i byte) {
+	p.setInfinity()
+	for j := range t {
+		p.selectFrom(&t[j], p, eq(byte(j), i))
+	}
+}
+
+// eq returns 1 if a = b, and 0 otherwise.
+func eq(a, b byte) int {
+	x := uint32(a ^ b)
+	return int((x - 1) >> 31)
+}
+
+// scalarMult sets p = k⋅q for the big-endian scalar k using a fixed 4-bit window.
+func (p *point) scalarMult(q *point, k *[32]byte) *point {
+	var t table
+	t.init(q)
+
+	var acc, s point
+	acc.setInfinity()
+	for _, b := range k {
+		for _, nibble := range [2]byte{b >> 4, b & 0x0f} {
+			acc.double(&acc)
+			acc.double(&acc)
+			acc.double(&acc)
+			acc.double(&acc)
+			t.lookup(&s, nibble)
+			acc.add(&acc, &s)
+		}
+	}
+	*p = acc
+	return p
+}
+
+// baseTables contains for every 4-bit window i the table of the multiples of 16ⁱ⋅G.
+var (
+	baseTables     *[64]table
+	baseTablesOnce sync.Once
+)
+
+func initBaseTables() {
+	baseTables = new([64]table)
+	var q point
+	q.setAffine(&generatorX, &generatorY)
+	for i := range baseTables {
+		baseTables[i].init(&q)
+		// q = 16⋅q
+		q.double(&q)
+		q.double(&q)
+		q.double(&q)
+		q.double(&q)
+	}
+}
+
+// scalarBaseMult sets p = k⋅G for the big-endian scalar k using the precomputed tables.
+func (p *point) scalarBaseMult(k *[32]byte) *point {
+	baseTablesOnce.Do(initBaseTables)
+
+	var acc, s point
+	acc.setInfinity()
+	for i := range baseTables {
+		// the i-th nibble starting with the least significant one
+		b := k[31-i/2]
+		nibble := b >> (4 * (i % 2)) & 0x0f
+		baseTables[i].lookup(&s, nibble)
+		acc.add(&acc, &s)
+	}
+	*p = acc
+	return p
+}
@@ -0,0 +1,185 @@
+// Package secp256k1 implements the secp256k1 curve used by Bitcoin with fixed-width field elements.
+//
+// In contrast to the generic implementation of package btccurve, scalar multiplications are constant time and
+// multiplications of the base point use precomputed tables. The big.Int values of the elliptic.Curve interface
+// are only used to convert the inputs and outputs.
+//
+// Only the point multiplications are constant time. Arithmetic modulo the group order, e.g. the private key
+// derivation in elliptic.PrivateKey.Shift or the nonce inversion during ECDSA signing, is still performed by the
+// callers using math/big and is not constant time.
+package secp256k1
+
+import (
+	"crypto/elliptic"
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+)
+
+// scalarN is the group order n in little-endian 64-bit limbs.
+var scalarN = [4]uint64{0xbfd25e8cd0364141, 0xbaaedce6af48a03b, 0xfffffffffffffffe, 0xffffffffffffffff}
+
+var (
+	generatorX, generatorY fieldElement
+	params                 *elliptic.CurveParams
+)
+
+func init() {
+	// See SEC 2 section 2.4.1
+	params = &elliptic.CurveParams{Name: "secp256k1", BitSize: 256}
+	params.P, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F", 16)
+	params.N, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", 16)
+	params.B, _ = new(big.Int).SetString("0000000000000000000000000000000000000000000000000000000000000007", 16)
+	params.Gx, _ = new(big.Int).SetString("79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798", 16)
+	params.Gy, _ = new(big.Int).SetString("483ADA7726A3C4655DA4FBFC0E1108A8FD17B448A68554199C47D08FFB10D4B8", 16)
+
+	generatorX = fieldElementFromBig(params.Gx)
+	generatorY = fieldElementFromBig(params.Gy)
+}
+
+type curve struct{}
+
+// Secp256k1 returns an elliptic curve which implements secp256k1 (SEC 2, section 2.4.1).
+func Secp256k1() elliptic.Curve {
+	return curve{}
+}
+
+func (curve) Params() *elliptic.CurveParams {
+	return params
+}
+
+// IsOnCurve reports whether (x, y) satisfies y² = x³ + 7.
+func (curve) IsOnCurve(x, y *big.Int) bool {
+	fx, ok1 := fieldElementFromCanonicalBig(x)
+	fy, ok2 := fieldElementFromCanonicalBig(y)
+	if !ok1 || !ok2 {
+		return false
+	}
+	return isOnCurve(&fx, &fy)
+}
+
+func (curve) Add(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
+	a, b := pointFromAffine(x1, y1), pointFromAffine(x2, y2)
+	return pointToAffine(a.add(a, b))
+}
+
+func (curve) Double(x1, y1 *big.Int) (*big.Int, *big.Int) {
+	a := pointFromAffine(x1, y1)
+	return pointToAffine(a.double(a))
+}
+
+func (curve) ScalarMult(x1, y1 *big.Int, k []byte) (*big.Int, *big.Int) {
+	scalar := reduceScalar(k)
+	a := pointFromAffine(x1, y1)
+	return pointToAffine(a.scalarMult(a, &scalar))
+}
+
+func (curve) ScalarBaseMult(k []byte) (*big.Int, *big.Int) {
+	scalar := reduceScalar(k)
+	return pointToAffine(new(point).scalarBaseMult(&scalar))
+}
+
+// UnmarshalCompressed converts a point, serialized by elliptic.MarshalCompressed, into an x, y pair.
+// It is an error if the point is not in compressed form or is not on the curve. On error, x = nil.
+func (curve) UnmarshalCompressed(data []byte) (x, y *big.Int) {
+	if len(data) != 33 || (data[0] != 2 && data[0] != 3) {
+		return nil, nil
+	}
+	var fx, fy, rhs fieldElement
+	if !fx.setBytes((*[32]byte)(data[1:])) {
+		return nil, nil
+	}
+	curveRHS(&rhs, &fx)
+	if !fy.sqrt(&rhs) {
+		return nil, nil
+	}
+	// choose the root with the requested parity
+	var negY fieldElement
+	negY.neg(&fy)
+	fy.selectFrom(&negY, &fy, fy.isOdd()^int(data[0]&1))
+	return fieldElementToBig(&fx), fieldElementToBig(&fy)
+}
+
+// curveRHS sets e = x³ + 7.
+func curveRHS(e, x *fieldElement) {
+	var seven fieldElement
+	seven.mul(&fieldElement{7}, &fieldR2)
+	e.square(x)
+	e.mul(e, x)
+	e.add(e, &seven)
+}
+
+func isOnCurve(x, y *fieldElement) bool {
+	var lhs, rhs fieldElement
+	lhs.square(y)
+	curveRHS(&rhs, x)
+	return lhs.equal(&rhs) == 1
+}
+
+// reduceScalar returns the big-endian encoding of k mod n.
+func reduceScalar(k []byte) [32]byte {
+	var out [32]byte
+	if len(k) > 32 {
+		new(big.Int).Mod(new(big.Int).SetBytes(k), params.N).FillBytes(out[:])
+		return out
+	}
+	copy(out[32-len(k):], k)
+
+	var a, s [4]uint64
+	for i := range a {
+		a[i] = binary.BigEndian.Uint64(out[24-8*i:])
+	}
+	// as k < 2²⁵⁶ < 2n, a single conditional subtraction suffices
+	var borrow uint64
+	for i := range s {
+		s[i], borrow = bits.Sub64(a[i], scalarN[i], borrow)
+	}
+	mask := borrow - 1 // use k - n, if there was no borrow
+	for i := range a {
+		binary.BigEndian.PutUint64(out[24-8*i:], s[i]&mask|a[i]&^mask)
+	}
+	return out
+}
+
+// pointFromAffine converts the affine point (x, y) into projective coordinates. (0, 0) denotes the point at
+// infinity. As in crypto/elliptic, the behavior for points not on the curve is undefined.
+func pointFromAffine(x, y *big.Int) *point {
+	p := new(point)
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return p.setInfinity()
+	}
+	fx, fy := fieldElementFromBig(x), fieldElementFromBig(y)
+	return p.setAffine(&fx, &fy)
+}
+
+func pointToAffine(p *point) (*big.Int, *big.Int) {
+	x, y := p.affine()
+	return fieldElementToBig(&x), fieldElementToBig(&y)
+}
+
+// fieldElementFromCanonicalBig converts x into a field element and reports whether 0 ≤ x < p.
+func fieldElementFromCanonicalBig(x *big.Int) (fieldElement, bool) {
+	var e fieldElement
+	if x.Sign() < 0 || x.BitLen() > 256 {
+		return e, false
+	}
+	var buf [32]byte
+	x.FillBytes(buf[:])
+	ok := e.setBytes(&buf)
+	return e, ok
+}
+
+// fieldElementFromBig converts x mod p into a field element.
+func fieldElementFromBig(x *big.Int) fieldElement {
+	e, ok := fieldElementFromCanonicalBig(x)
+	if !ok {
+		e, _ = fieldElementFromCanonicalBig(new(big.Int).Mod(x, params.P))
+	}
+	return e
+}
+
+func fieldElementToBig(e *fieldElement) *big.Int {
+	var buf [32]byte
+	e.bytes(&buf)
+	return new(big.Int).SetBytes(buf[:])
+}
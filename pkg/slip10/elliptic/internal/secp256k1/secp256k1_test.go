@@ -0,0 +1,203 @@
+package secp256k1_test
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/elliptic/internal/btccurve"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/elliptic/internal/secp256k1"
+)
+
+// the generic big.Int implementation serves as reference
+var (
+	curve     = secp256k1.Secp256k1()
+	reference = btccurve.Secp256k1()
+	n         = reference.Params().N
+)
+
+type compressedUnmarshaler interface {
+	UnmarshalCompressed(data []byte) (x, y *big.Int)
+}
+
+func assertEqualPoint(t *testing.T, expectedX, expectedY, actualX, actualY *big.Int) {
+	assert.Zerof(t, expectedX.Cmp(actualX), "x: expected %s, actual %s", expectedX, actualX)
+	assert.Zerof(t, expectedY.Cmp(actualY), "y: expected %s, actual %s", expectedY, actualY)
+}
+
+func randomScalar(t testing.TB) []byte {
+	k, err := rand.Int(rand.Reader, n)
+	require.NoError(t, err)
+	return k.FillBytes(make([]byte, 32))
+}
+
+func randomPoint(t testing.TB) (x, y *big.Int) {
+	return reference.ScalarBaseMult(randomScalar(t))
+}
+
+func TestParams(t *testing.T) {
+	assert.Equal(t, reference.Params(), curve.Params())
+}
+
+func TestScalarBaseMult(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		k := randomScalar(t)
+		x1, y1 := reference.ScalarBaseMult(k)
+		x2, y2 := curve.ScalarBaseMult(k)
+		assert.Equal(t, x1, x2)
+		assert.Equal(t, y1, y2)
+	}
+}
+
+func TestScalarMult(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		x, y := randomPoint(t)
+		k := randomScalar(t)
+		x1, y1 := reference.ScalarMult(x, y, k)
+		x2, y2 := curve.ScalarMult(x, y, k)
+		assert.Equal(t, x1, x2)
+		assert.Equal(t, y1, y2)
+	}
+}
+
+func TestScalarMultEdgeCases(t *testing.T) {
+	one := big.NewInt(1)
+	var tests = []*struct {
+		name string
+		k    *big.Int
+	}{
+		{"zero", new(big.Int)},
+		{"one", one},
+		{"n-1", new(big.Int).Sub(n, one)},
+		{"n", n},
+		{"n+1", new(big.Int).Add(n, one)},
+		{"2^256-1", new(big.Int).Sub(new(big.Int).Lsh(one, 256), one)},
+		{"2^512", new(big.Int).Lsh(one, 512)},
+	}
+	x, y := randomPoint(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := new(big.Int).Mod(tt.k, n)
+			ex, ey := new(big.Int), new(big.Int)
+			if k.Sign() != 0 {
+				ex, ey = reference.ScalarBaseMult(k.Bytes())
+			}
+			bx, by := curve.ScalarBaseMult(tt.k.Bytes())
+			assertEqualPoint(t, ex, ey, bx, by)
+
+			if k.Sign() != 0 {
+				ex, ey = reference.ScalarMult(x, y, k.Bytes())
+			}
+			px, py := curve.ScalarMult(x, y, tt.k.Bytes())
+			assertEqualPoint(t, ex, ey, px, py)
+		})
+	}
+}
+
+func TestAdd(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		x1, y1 := randomPoint(t)
+		x2, y2 := randomPoint(t)
+		ex, ey := reference.Add(x1, y1, x2, y2)
+		ax, ay := curve.Add(x1, y1, x2, y2)
+		assert.Equal(t, ex, ax)
+		assert.Equal(t, ey, ay)
+
+		ex, ey = reference.Double(x1, y1)
+		dx, dy := curve.Double(x1, y1)
+		assert.Equal(t, ex, dx)
+		assert.Equal(t, ey, dy)
+	}
+}
+
+func TestAddEdgeCases(t *testing.T) {
+	x, y := randomPoint(t)
+	negY := new(big.Int).Sub(curve.Params().P, y)
+	zero := new(big.Int)
+
+	// P + P = 2⋅P
+	ex, ey := reference.Double(x, y)
+	ax, ay := curve.Add(x, y, x, y)
+	assert.Equal(t, ex, ax)
+	assert.Equal(t, ey, ay)
+
+	// P + (-P) = 0
+	ax, ay = curve.Add(x, y, x, negY)
+	assert.Zero(t, ax.Sign())
+	assert.Zero(t, ay.Sign())
+
+	// P + 0 = 0 + P = P
+	ax, ay = curve.Add(x, y, zero, zero)
+	assert.Equal(t, x, ax)
+	assert.Equal(t, y, ay)
+	ax, ay = curve.Add(zero, zero, x, y)
+	assert.Equal(t, x, ax)
+	assert.Equal(t, y, ay)
+
+	// 2⋅0 = 0
+	dx, dy := curve.Double(zero, zero)
+	assert.Zero(t, dx.Sign())
+	assert.Zero(t, dy.Sign())
+}
+
+func TestIsOnCurve(t *testing.T) {
+	x, y := randomPoint(t)
+	assert.True(t, curve.IsOnCurve(x, y))
+	assert.False(t, curve.IsOnCurve(x, new(big.Int).Add(y, big.NewInt(1))))
+	assert.False(t, curve.IsOnCurve(x, new(big.Int).Add(y, curve.Params().P)))
+	assert.False(t, curve.IsOnCurve(new(big.Int), new(big.Int)))
+}
+
+func TestUnmarshalCompressed(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		x, y := randomPoint(t)
+		data := elliptic.MarshalCompressed(curve, x, y)
+		ex, ey := reference.(compressedUnmarshaler).UnmarshalCompressed(data)
+		ax, ay := curve.(compressedUnmarshaler).UnmarshalCompressed(data)
+		require.NotNil(t, ax)
+		assert.Equal(t, ex, ax)
+		assert.Equal(t, ey, ay)
+	}
+
+	// x = p is not a valid coordinate
+	data := append([]byte{2}, curve.Params().P.Bytes()...)
+	x, _ := curve.(compressedUnmarshaler).UnmarshalCompressed(data)
+	assert.Nil(t, x)
+	// x = 5 does not correspond to a point, as 5³ + 7 is not a square
+	data = append([]byte{2}, make([]byte, 31)...)
+	data = append(data, 5)
+	x, _ = curve.(compressedUnmarshaler).UnmarshalCompressed(data)
+	assert.Nil(t, x)
+}
+
+func BenchmarkScalarBaseMult(b *testing.B) {
+	k := randomScalar(b)
+	b.Run("btccurve", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			reference.ScalarBaseMult(k)
+		}
+	})
+	b.Run("secp256k1", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			curve.ScalarBaseMult(k)
+		}
+	})
+}
+
+func BenchmarkScalarMult(b *testing.B) {
+	x, y := randomPoint(b)
+	k := randomScalar(b)
+	b.Run("btccurve", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			reference.ScalarMult(x, y, k)
+		}
+	})
+	b.Run("secp256k1", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			curve.ScalarMult(x, y, k)
+		}
+	})
+}
@@ -38,6 +38,16 @@ func (p *PrivateKey) Public() slip10.Key {
 	return &PublicKey{x, y, p.Curve}
 }
 
+// Wipe overwrites the words of the secret scalar with zeros and sets it to zero.
+func (p *PrivateKey) Wipe() {
+	wipeInt(p.K)
+}
+
+// Clone returns a copy of the key.
+func (p *PrivateKey) Clone() slip10.Key {
+	return &PrivateKey{new(big.Int).Set(p.K), p.Curve}
+}
+
 // Shift derives a new PrivateKey using the provided additive shift.
 // It returns ErrInvalidKey if the shift leads to an invalid key.
 func (p *PrivateKey) Shift(buf []byte) (slip10.Key, error) {
@@ -73,6 +83,16 @@ func (p *PublicKey) Bytes() []byte {
 	return elliptic.MarshalCompressed(p.Curve, p.X, p.Y)
 }
 
+// UncompressedBytes returns the key in the uncompressed SEC1 encoding 0x04 || x || y.
+func (p *PublicKey) UncompressedBytes() []byte {
+	byteLen := (p.Curve.Params().BitSize + 7) / 8
+	buf := make([]byte, 1+2*byteLen)
+	buf[0] = 0x04
+	p.X.FillBytes(buf[1 : 1+byteLen])
+	p.Y.FillBytes(buf[1+byteLen:])
+	return buf
+}
+
 // IsPrivate always returns false.
 func (*PublicKey) IsPrivate() bool {
 	return false
@@ -0,0 +1,93 @@
+package elliptic_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/eddsa"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+func TestParsePublicKey(t *testing.T) {
+	for name, curve := range curves {
+		t.Run(name, func(t *testing.T) {
+			k, err := curve.NewPrivateKey(bytes.Repeat([]byte{0x01}, 32))
+			require.NoError(t, err)
+			public := k.Public().(*elliptic.PublicKey)
+
+			key, err := elliptic.ParsePublicKey(curve, public.Bytes())
+			require.NoError(t, err)
+			assert.Equal(t, public.Bytes(), key.Bytes())
+
+			key, err = elliptic.ParsePublicKey(curve, public.UncompressedBytes())
+			require.NoError(t, err)
+			assert.Equal(t, public.Bytes(), key.Bytes())
+
+			// the parsed key must be usable as slip10.Key
+			var parsed slip10.Key
+			parsed, err = curve.(slip10.PublicKeyParser).NewPublicKey(public.UncompressedBytes())
+			require.NoError(t, err)
+			_, err = parsed.Shift(make([]byte, 32))
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestParsePublicKeyInvalid(t *testing.T) {
+	for name, curve := range curves {
+		t.Run(name, func(t *testing.T) {
+			k, err := curve.NewPrivateKey(bytes.Repeat([]byte{0x01}, 32))
+			require.NoError(t, err)
+			public := k.Public().(*elliptic.PublicKey)
+			p := public.Curve.Params().P
+
+			// first x-coordinate for which no point exists on the respective curve
+			noPointX := map[string]byte{"secp256k1": 5, "nist256p1": 1}[name]
+
+			var tests = []*struct {
+				name string
+				buf  []byte
+				err  error
+			}{
+				{"empty", nil, elliptic.ErrInvalidEncoding},
+				{"identity", []byte{0x00}, elliptic.ErrIdentity},
+				{"uncompressed identity", make65(0x04, nil, nil), elliptic.ErrIdentity},
+				{"invalid prefix", append([]byte{0x05}, public.Bytes()[1:]...), elliptic.ErrInvalidEncoding},
+				{"invalid length", public.Bytes()[:32], elliptic.ErrInvalidEncoding},
+				{"compressed x = p", append([]byte{0x02}, p.Bytes()...), elliptic.ErrInvalidEncoding},
+				{"compressed not on curve", append([]byte{0x02}, new(big.Int).SetInt64(int64(noPointX)).FillBytes(make([]byte, 32))...), elliptic.ErrNotOnCurve},
+				{"uncompressed y = p", make65(0x04, public.X, p), elliptic.ErrInvalidEncoding},
+				{"uncompressed not on curve", make65(0x04, public.X, new(big.Int).Add(public.Y, big.NewInt(1))), elliptic.ErrNotOnCurve},
+			}
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					_, err := elliptic.ParsePublicKey(curve, tt.buf)
+					assert.ErrorIs(t, err, tt.err)
+					assert.ErrorIs(t, err, elliptic.ErrInvalidPublicKey)
+					assert.ErrorIs(t, err, slip10.ErrInvalidKey)
+				})
+			}
+		})
+	}
+}
+
+func TestParsePublicKeyUnsupportedCurve(t *testing.T) {
+	_, err := elliptic.ParsePublicKey(eddsa.Ed25519(), make([]byte, 33))
+	assert.ErrorIs(t, err, elliptic.ErrUnsupportedCurve)
+}
+
+func make65(prefix byte, x, y *big.Int) []byte {
+	buf := make([]byte, 65)
+	buf[0] = prefix
+	if x != nil {
+		x.FillBytes(buf[1:33])
+	}
+	if y != nil {
+		y.FillBytes(buf[33:])
+	}
+	return buf
+}
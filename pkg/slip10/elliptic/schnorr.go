@@ -0,0 +1,239 @@
+package elliptic
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/wollac/iota-crypto-demo/internal/wipe"
+)
+
+const (
+	// XOnlyPublicKeySize is the size, in bytes, of x-only public keys as defined in BIP-340.
+	XOnlyPublicKeySize = 32
+	// SchnorrSignatureSize is the size, in bytes, of BIP-340 Schnorr signatures.
+	SchnorrSignatureSize = 64
+	// AuxRandSize is the size, in bytes, of the auxiliary randomness used for Schnorr signing.
+	AuxRandSize = 32
+)
+
+// Tags of the BIP-340 tagged hashes.
+const (
+	tagAux       = "BIP0340/aux"
+	tagNonce     = "BIP0340/nonce"
+	tagChallenge = "BIP0340/challenge"
+)
+
+// ErrInvalidAuxRand is returned when the auxiliary randomness for Schnorr signing has an invalid length.
+var ErrInvalidAuxRand = errors.New("invalid auxiliary randomness length")
+
+// TaggedHash returns the BIP-340 tagged hash SHA256(SHA256(tag) || SHA256(tag) || data).
+func TaggedHash(tag string, data ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// ParseXOnlyPublicKey parses the 32-byte x-only encoding of a secp256k1 public key.
+// The returned key is the point with the given x-coordinate and an even y-coordinate.
+func ParseXOnlyPublicKey(buf []byte) (*PublicKey, error) {
+	if len(buf) != XOnlyPublicKeySize {
+		return nil, ErrInvalidEncoding
+	}
+	x, y := liftX(buf)
+	if x == nil {
+		return nil, ErrNotOnCurve
+	}
+	return &PublicKey{x, y, secp256k1.Curve.Curve}, nil
+}
+
+// XOnly returns the 32-byte x-only encoding of the public key as defined in BIP-340.
+func (p *PublicKey) XOnly() []byte {
+	return scalarBytes(p.X)
+}
+
+// SignSchnorr signs the message msg with the private key as defined in BIP-340.
+// The auxiliary randomness auxRand must be either nil or AuxRandSize bytes; if it is nil, fresh randomness is used.
+// Only secp256k1 keys are supported.
+func (p *PrivateKey) SignSchnorr(msg, auxRand []byte) ([]byte, error) {
+	if !isSecp256k1(p.Curve) {
+		return nil, ErrUnsupportedCurve
+	}
+	if auxRand == nil {
+		auxRand = make([]byte, AuxRandSize)
+		if _, err := io.ReadFull(rand.Reader, auxRand); err != nil {
+			return nil, err
+		}
+	}
+	if len(auxRand) != AuxRandSize {
+		return nil, ErrInvalidAuxRand
+	}
+	n := p.Curve.Params().N
+
+	// use the secret key d corresponding to the point with even y-coordinate
+	d := new(big.Int).Set(p.K)
+	defer wipeInt(d)
+	t := scalarBytes(d)
+	defer wipe.Bytes(t)
+	px, py := p.Curve.ScalarBaseMult(t)
+	if py.Bit(0) != 0 {
+		d.Sub(n, d)
+		d.FillBytes(t)
+		py.Sub(p.Curve.Params().P, py)
+	}
+	pubBytes := scalarBytes(px)
+
+	// t = bytes(d) xor hash_aux(a)
+	for i, b := range TaggedHash(tagAux, auxRand) {
+		t[i] ^= b
+	}
+
+	k := new(big.Int).SetBytes(TaggedHash(tagNonce, t, pubBytes, msg))
+	defer wipeInt(k)
+	k.Mod(k, n)
+	if k.Sign() == 0 {
+		return nil, errors.New("failed to sign: nonce is zero")
+	}
+	kBytes := scalarBytes(k)
+	defer wipe.Bytes(kBytes)
+	rx, ry := p.Curve.ScalarBaseMult(kBytes)
+	if ry.Bit(0) != 0 {
+		k.Sub(n, k)
+	}
+
+	sig := make([]byte, SchnorrSignatureSize)
+	rx.FillBytes(sig[:32])
+	e := challenge(sig[:32], pubBytes, msg)
+
+	// s = k + e⋅d mod n
+	s := e.Mul(e, d)
+	s.Add(s, k)
+	s.Mod(s, n)
+	s.FillBytes(sig[32:])
+
+	// verify the signature to protect against fault attacks
+	if !verifySchnorr(px, py, msg, sig) {
+		return nil, errors.New("failed to sign: signature verification failed")
+	}
+	return sig, nil
+}
+
+// VerifySchnorr reports whether sig is a valid BIP-340 signature of msg by the public key.
+// As BIP-340 only uses the x-coordinate of public keys, the key is treated as its x-only equivalent.
+func (p *PublicKey) VerifySchnorr(msg, sig []byte) bool {
+	if !isSecp256k1(p.Curve) || len(sig) != SchnorrSignatureSize {
+		return false
+	}
+	// switch to the point with even y-coordinate
+	py := p.Y
+	if py.Bit(0) != 0 {
+		py = new(big.Int).Sub(p.Curve.Params().P, py)
+	}
+	return verifySchnorr(p.X, py, msg, sig)
+}
+
+// VerifySchnorrBatch reports whether all signatures sigs[i] of the messages msgs[i] by the public keys keys[i] are
+// valid. It uses the batch verification algorithm of BIP-340 with randomly chosen coefficients, which is faster than
+// verifying each signature individually, but it does not reveal which signature is invalid.
+func VerifySchnorrBatch(keys []*PublicKey, msgs, sigs [][]byte) bool {
+	if len(keys) != len(msgs) || len(keys) != len(sigs) {
+		return false
+	}
+	if len(keys) == 0 {
+		return true
+	}
+	curve := secp256k1.Curve.Curve
+	n := curve.Params().N
+
+	// check that s₁⋅G + a₂⋅s₂⋅G + … = R₁ + a₂⋅R₂ + … + e₁⋅P₁ + a₂⋅e₂⋅P₂ + …
+	sum := new(big.Int)
+	var lx, ly *big.Int
+	for i := range keys {
+		key, sig := keys[i], sigs[i]
+		if !isSecp256k1(key.Curve) || len(sig) != SchnorrSignatureSize {
+			return false
+		}
+		px, py := liftX(key.XOnly())
+		rx, ry := liftX(sig[:32])
+		s := new(big.Int).SetBytes(sig[32:])
+		if px == nil || rx == nil || s.Cmp(n) >= 0 {
+			return false
+		}
+		e := challenge(sig[:32], key.XOnly(), msgs[i])
+
+		// the first coefficient is 1, all others are random
+		a := big.NewInt(1)
+		if i > 0 {
+			var err error
+			if a, err = randScalar(n); err != nil {
+				return false
+			}
+		}
+		sum.Add(sum, s.Mul(s, a))
+		rx, ry = curve.ScalarMult(rx, ry, scalarBytes(a))
+		px, py = curve.ScalarMult(px, py, scalarBytes(e.Mod(e.Mul(e, a), n)))
+		x, y := curve.Add(rx, ry, px, py)
+		if lx == nil {
+			lx, ly = x, y
+		} else {
+			lx, ly = curve.Add(lx, ly, x, y)
+		}
+	}
+	rx, ry := curve.ScalarBaseMult(scalarBytes(sum.Mod(sum, n)))
+	return rx.Cmp(lx) == 0 && ry.Cmp(ly) == 0
+}
+
+// verifySchnorr verifies the signature for the point (px, py) with even y-coordinate.
+func verifySchnorr(px, py *big.Int, msg, sig []byte) bool {
+	curve := secp256k1.Curve.Curve
+	params := curve.Params()
+
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:])
+	if r.Cmp(params.P) >= 0 || s.Cmp(params.N) >= 0 {
+		return false
+	}
+	e := challenge(sig[:32], scalarBytes(px), msg)
+
+	// R = s⋅G - e⋅P
+	x1, y1 := curve.ScalarBaseMult(scalarBytes(s))
+	x2, y2 := curve.ScalarMult(px, py, scalarBytes(e.Mod(e.Sub(params.N, e), params.N)))
+	x, y := curve.Add(x1, y1, x2, y2)
+	// fail if R is the point at infinity, has an odd y-coordinate or a different x-coordinate
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return false
+	}
+	return y.Bit(0) == 0 && x.Cmp(r) == 0
+}
+
+// challenge computes the BIP-340 challenge e = int(hash_challenge(bytes(R) || bytes(P) || m)) mod n.
+func challenge(r, p, msg []byte) *big.Int {
+	e := new(big.Int).SetBytes(TaggedHash(tagChallenge, r, p, msg))
+	return e.Mod(e, secp256k1.Params().N)
+}
+
+// liftX returns the secp256k1 point with the given x-coordinate and an even y-coordinate. On error, x = nil.
+func liftX(buf []byte) (x, y *big.Int) {
+	return unmarshalCompressed(secp256k1.Curve.Curve, append([]byte{2}, buf...))
+}
+
+func isSecp256k1(curve elliptic.Curve) bool {
+	return curve.Params() == secp256k1.Params()
+}
+
+// randScalar returns a uniformly random scalar in [1, n-1].
+func randScalar(n *big.Int) (*big.Int, error) {
+	k, err := rand.Int(rand.Reader, new(big.Int).Sub(n, big.NewInt(1)))
+	if err != nil {
+		return nil, err
+	}
+	return k.Add(k, big.NewInt(1)), nil
+}
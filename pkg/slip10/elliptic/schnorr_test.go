@@ -0,0 +1,96 @@
+package elliptic_test
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+// TestSchnorr uses the official BIP-340 test vectors.
+func TestSchnorr(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", t.Name()+".csv"))
+	require.NoError(t, err)
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	require.NoError(t, err)
+
+	for _, r := range records[1:] {
+		index, secretKey, publicKey, auxRand, msg, sig, result, comment := r[0], decode(r[1]), decode(r[2]), decode(r[3]), decode(r[4]), decode(r[5]), r[6] == "TRUE", r[7]
+		t.Run(index, func(t *testing.T) {
+			if len(secretKey) > 0 {
+				k, err := elliptic.Secp256k1().NewPrivateKey(secretKey)
+				require.NoError(t, err)
+				key := k.(*elliptic.PrivateKey)
+				assert.Equal(t, publicKey, key.Public().(*elliptic.PublicKey).XOnly())
+
+				actual, err := key.SignSchnorr(msg, auxRand)
+				require.NoError(t, err)
+				assert.Equal(t, sig, actual)
+			}
+
+			key, err := elliptic.ParseXOnlyPublicKey(publicKey)
+			if err != nil {
+				assert.ErrorIs(t, err, elliptic.ErrInvalidPublicKey)
+				assert.False(t, result, comment)
+				return
+			}
+			assert.Equal(t, result, key.VerifySchnorr(msg, sig), comment)
+			assert.Equal(t, result, elliptic.VerifySchnorrBatch([]*elliptic.PublicKey{key}, [][]byte{msg}, [][]byte{sig}), comment)
+		})
+	}
+}
+
+func TestVerifySchnorrBatch(t *testing.T) {
+	var (
+		keys []*elliptic.PublicKey
+		msgs [][]byte
+		sigs [][]byte
+	)
+	for i := 0; i < 5; i++ {
+		key, err := slip10.DeriveKeyFromPath(decode("000102030405060708090a0b0c0d0e0f"), elliptic.Secp256k1(), []uint32{uint32(i)})
+		require.NoError(t, err)
+		msg := []byte{byte(i)}
+		sig, err := key.Key.(*elliptic.PrivateKey).SignSchnorr(msg, nil)
+		require.NoError(t, err)
+
+		public := key.Key.Public().(*elliptic.PublicKey)
+		require.True(t, public.VerifySchnorr(msg, sig))
+		keys, msgs, sigs = append(keys, public), append(msgs, msg), append(sigs, sig)
+	}
+	assert.True(t, elliptic.VerifySchnorrBatch(keys, msgs, sigs))
+
+	// swapping two messages must invalidate the batch
+	msgs[1], msgs[2] = msgs[2], msgs[1]
+	assert.False(t, elliptic.VerifySchnorrBatch(keys, msgs, sigs))
+	assert.False(t, elliptic.VerifySchnorrBatch(keys, msgs[:4], sigs))
+}
+
+func TestSignSchnorrUnsupportedCurve(t *testing.T) {
+	key, err := elliptic.Nist256p1().NewPrivateKey(decode("c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721"))
+	require.NoError(t, err)
+	_, err = key.(*elliptic.PrivateKey).SignSchnorr(nil, nil)
+	assert.ErrorIs(t, err, elliptic.ErrUnsupportedCurve)
+}
+
+func TestTaggedHash(t *testing.T) {
+	// the tagged hash of the empty message using the tag "BIP0340/challenge"
+	assert.Equal(t,
+		"c216d352f5818b7b4beacd4ae0a26fe888080823d2a598856661bcd54f1b3713",
+		hex.EncodeToString(elliptic.TaggedHash("BIP0340/challenge")),
+	)
+}
+
+func decode(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
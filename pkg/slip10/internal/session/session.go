@@ -0,0 +1,19 @@
+// Package session derives symmetric session keys from the shared secrets of the key agreement schemes.
+package session
+
+import (
+	"crypto/sha256"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// Key derives a symmetric key of the given size from the shared secret.
+// The key is derived using HKDF-SHA256 (RFC 5869) with the optional salt and the application specific info.
+func Key(secret, salt, info []byte, size int) ([]byte, error) {
+	key := make([]byte, size)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, info), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
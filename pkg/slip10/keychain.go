@@ -0,0 +1,173 @@
+package slip10
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/wollac/iota-crypto-demo/pkg/bip32path"
+)
+
+// Keychain derives keys from a master key and memoizes the intermediate nodes.
+// When many keys sharing a common path prefix are derived, e.g. all addresses of an account,
+// each intermediate node is only computed once.
+// It is safe for concurrent use by multiple goroutines, with the exception of Wipe.
+type Keychain struct {
+	// Workers is the number of goroutines used by DeriveRange.
+	// If Workers is zero or negative, runtime.GOMAXPROCS(0) is used.
+	Workers int
+
+	master *ExtendedKey
+
+	mu    sync.RWMutex
+	nodes map[string]*ExtendedKey
+}
+
+// DerivedKey is the result of a single derivation in Keychain.DeriveRange.
+type DerivedKey struct {
+	// Index is the index that was used to derive the key from the base path.
+	Index uint32
+	// Key is the derived extended key or nil, if the derivation failed.
+	Key *ExtendedKey
+	// Err is the error that occurred during the derivation.
+	Err error
+}
+
+// NewKeychain creates a new Keychain for the given master key.
+func NewKeychain(master *ExtendedKey) *Keychain {
+	return &Keychain{
+		master: master,
+		nodes:  make(map[string]*ExtendedKey),
+	}
+}
+
+// DeriveKey derives the extended key at path relative to the master key of the keychain.
+// All intermediate nodes are cached, so that consecutive calls sharing a prefix only derive the remaining indices.
+// For an empty path, a copy of the master key is returned, which can be wiped without affecting the keychain.
+func (k *Keychain) DeriveKey(path bip32path.Path) (*ExtendedKey, error) {
+	if len(path) == 0 {
+		return k.master.clone(), nil
+	}
+	parent, err := k.node(path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	return parent.DeriveChild(path[len(path)-1])
+}
+
+// DeriveRange derives the children of basePath with the indices from (inclusive) to to (exclusive) in parallel.
+// The indices are used as is, i.e. for hardened derivation they must include Hardened.
+// The results are sent on the returned channel in no particular order. The channel is closed after all keys have
+// been derived, or when ctx is canceled; in the latter case some results are not sent.
+func (k *Keychain) DeriveRange(ctx context.Context, basePath bip32path.Path, from, to uint32) <-chan DerivedKey {
+	results := make(chan DerivedKey)
+
+	base, err := k.node(basePath)
+	if err != nil {
+		go func() {
+			defer close(results)
+			select {
+			case results <- DerivedKey{Index: from, Err: err}:
+			case <-ctx.Done():
+			}
+		}()
+		return results
+	}
+
+	workers := k.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	indices := make(chan uint32)
+	go func() {
+		defer close(indices)
+		for i := from; i < to; i++ {
+			select {
+			case indices <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				key, err := base.DeriveChild(i)
+				select {
+				case results <- DerivedKey{Index: i, Key: key, Err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+// Wipe wipes and removes all cached intermediate nodes.
+// The master key is owned by the caller and is not wiped. Keys returned by DeriveKey and DeriveRange are not affected.
+// As the cached nodes are used for derivation outside of the lock, Wipe must not be called concurrently with DeriveKey
+// or before the channel returned by DeriveRange has been closed. Afterwards, the keychain can be used again.
+func (k *Keychain) Wipe() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for path, node := range k.nodes {
+		node.Wipe()
+		delete(k.nodes, path)
+	}
+}
+
+// node returns the node at path and caches it together with all its ancestors.
+func (k *Keychain) node(path bip32path.Path) (*ExtendedKey, error) {
+	// find the longest cached prefix
+	n := len(path)
+	k.mu.RLock()
+	node := k.master
+	for ; n > 0; n-- {
+		if cached, ok := k.nodes[pathKey(path[:n])]; ok {
+			node = cached
+			break
+		}
+	}
+	k.mu.RUnlock()
+
+	// derive and cache the remaining nodes
+	for ; n < len(path); n++ {
+		child, err := node.DeriveChild(path[n])
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive %s: %w", path[:n+1], err)
+		}
+		node = child
+
+		k.mu.Lock()
+		if cached, ok := k.nodes[pathKey(path[:n+1])]; ok {
+			// another goroutine was faster, use its node to keep the cache consistent
+			node = cached
+		} else {
+			k.nodes[pathKey(path[:n+1])] = node
+		}
+		k.mu.Unlock()
+	}
+	return node, nil
+}
+
+// pathKey returns a compact string representation of path to be used as a map key.
+func pathKey(path bip32path.Path) string {
+	b := make([]byte, 4*len(path))
+	for i, index := range path {
+		binary.BigEndian.PutUint32(b[4*i:], index)
+	}
+	return string(b)
+}
@@ -0,0 +1,183 @@
+package slip10_test
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wollac/iota-crypto-demo/pkg/bip32path"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/ecdh"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/eddsa"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+var keychainSeed, _ = hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+func newKeychain(t testing.TB, curve slip10.Curve) *slip10.Keychain {
+	master, err := slip10.NewMasterKey(keychainSeed, curve)
+	require.NoError(t, err)
+	return slip10.NewKeychain(master)
+}
+
+func TestKeychainDeriveKey(t *testing.T) {
+	var tests = []string{
+		"m",
+		"m/44'",
+		"m/44'/4218'/0'/0'/0'",
+		"m/44'/4218'/0'/0'/1'",
+		"m/44'/4218'/0'/1'/0'",
+		"m/44'/4218'/0'",
+	}
+	keychain := newKeychain(t, eddsa.Ed25519())
+	for _, s := range tests {
+		t.Run(s, func(t *testing.T) {
+			path, err := bip32path.ParsePath(s)
+			require.NoError(t, err)
+
+			expected, err := slip10.DeriveKeyFromPath(keychainSeed, eddsa.Ed25519(), path)
+			require.NoError(t, err)
+			key, err := keychain.DeriveKey(path)
+			require.NoError(t, err)
+			assert.Equal(t, expected.ChainCode, key.ChainCode)
+			assert.Equal(t, expected.Key.Bytes(), key.Key.Bytes())
+			assert.Equal(t, expected.Fingerprint(), key.Fingerprint())
+			assertOrigin(t, path, key)
+		})
+	}
+}
+
+func TestKeychainDeriveKeyMaster(t *testing.T) {
+	for _, curve := range []slip10.Curve{elliptic.Secp256k1(), eddsa.Ed25519(), eddsa.BIP32Ed25519(), ecdh.X25519()} {
+		t.Run(curve.Name(), func(t *testing.T) {
+			master, err := slip10.NewMasterKey(keychainSeed, curve)
+			require.NoError(t, err)
+			keychain := slip10.NewKeychain(master)
+
+			for _, path := range []bip32path.Path{nil, {}} {
+				key, err := keychain.DeriveKey(path)
+				require.NoError(t, err)
+				require.NotSame(t, master, key)
+				assert.Equal(t, master.Key.Bytes(), key.Key.Bytes())
+				assert.Equal(t, master.ChainCode, key.ChainCode)
+
+				// wiping the returned key must not affect the master key of the keychain
+				key.Wipe()
+				expected, err := slip10.DeriveKeyFromPath(keychainSeed, curve, bip32path.Path{0 | slip10.Hardened})
+				require.NoError(t, err)
+				child, err := keychain.DeriveKey(bip32path.Path{0 | slip10.Hardened})
+				require.NoError(t, err)
+				assert.Equal(t, expected.Key.Bytes(), child.Key.Bytes())
+				assert.Equal(t, expected.ChainCode, child.ChainCode)
+			}
+		})
+	}
+}
+
+func TestKeychainDeriveRange(t *testing.T) {
+	const from, to = 10, 50
+	keychain := newKeychain(t, elliptic.Secp256k1())
+	keychain.Workers = 4
+
+	basePath := bip32path.Path{44 | slip10.Hardened, 0 | slip10.Hardened, 0 | slip10.Hardened, 0}
+	results := map[uint32]*slip10.ExtendedKey{}
+	for r := range keychain.DeriveRange(context.Background(), basePath, from, to) {
+		require.NoError(t, r.Err)
+		assert.NotContains(t, results, r.Index)
+		results[r.Index] = r.Key
+	}
+	require.Len(t, results, to-from)
+
+	for i := uint32(from); i < to; i++ {
+		expected, err := slip10.DeriveKeyFromPath(keychainSeed, elliptic.Secp256k1(), append(basePath, i))
+		require.NoError(t, err)
+		assert.Equal(t, expected.String(), results[i].String())
+	}
+}
+
+func TestKeychainWipeFingerprint(t *testing.T) {
+	keychain := newKeychain(t, elliptic.Secp256k1())
+
+	basePath := bip32path.Path{44 | slip10.Hardened, 0 | slip10.Hardened, 0 | slip10.Hardened, 0}
+	path := append(basePath[:len(basePath):len(basePath)], 0)
+	key, err := keychain.DeriveKey(path)
+	require.NoError(t, err)
+	var rangeKey *slip10.ExtendedKey
+	for r := range keychain.DeriveRange(context.Background(), basePath, 1, 2) {
+		require.NoError(t, r.Err)
+		rangeKey = r.Key
+	}
+	require.NotNil(t, rangeKey)
+
+	keychain.Wipe()
+
+	// the returned keys must still have the correct fingerprint and serialization
+	expected, err := slip10.DeriveKeyFromPath(keychainSeed, elliptic.Secp256k1(), path)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Fingerprint(), key.Fingerprint())
+	assert.Equal(t, expected.String(), key.String())
+
+	expected, err = slip10.DeriveKeyFromPath(keychainSeed, elliptic.Secp256k1(), append(basePath, 1))
+	require.NoError(t, err)
+	assert.Equal(t, expected.Fingerprint(), rangeKey.Fingerprint())
+	assert.Equal(t, expected.String(), rangeKey.String())
+}
+
+func TestKeychainDeriveRangeError(t *testing.T) {
+	master, err := slip10.NewMasterKey(keychainSeed, elliptic.Secp256k1())
+	require.NoError(t, err)
+	keychain := slip10.NewKeychain(master.Public())
+
+	// hardened derivation from a public key must fail for each index
+	var n int
+	for r := range keychain.DeriveRange(context.Background(), bip32path.Path{0}, 0|slip10.Hardened, 5|slip10.Hardened) {
+		assert.ErrorIs(t, r.Err, slip10.ErrHardenedChildPublicKey)
+		n++
+	}
+	assert.Equal(t, 5, n)
+
+	// derivation of the base path must fail once
+	n = 0
+	for r := range keychain.DeriveRange(context.Background(), bip32path.Path{0 | slip10.Hardened}, 0, 5) {
+		assert.ErrorIs(t, r.Err, slip10.ErrHardenedChildPublicKey)
+		n++
+	}
+	assert.Equal(t, 1, n)
+}
+
+func TestKeychainDeriveRangeCancel(t *testing.T) {
+	keychain := newKeychain(t, eddsa.Ed25519())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var n int
+	for r := range keychain.DeriveRange(ctx, nil, 0|slip10.Hardened, 1<<20|slip10.Hardened) {
+		require.NoError(t, r.Err)
+		if n++; n == 10 {
+			cancel()
+		}
+	}
+	assert.Less(t, n, 1<<20)
+}
+
+func BenchmarkKeychainDeriveKey(b *testing.B) {
+	keychain := newKeychain(b, eddsa.Ed25519())
+	path := bip32path.Path{44 | slip10.Hardened, 4218 | slip10.Hardened, 0 | slip10.Hardened, 0 | slip10.Hardened, 0}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		path[4] = uint32(i) | slip10.Hardened
+		_, _ = keychain.DeriveKey(path)
+	}
+}
+
+func BenchmarkKeychainDeriveRange(b *testing.B) {
+	keychain := newKeychain(b, eddsa.Ed25519())
+	basePath := bip32path.Path{44 | slip10.Hardened, 4218 | slip10.Hardened, 0 | slip10.Hardened, 0 | slip10.Hardened}
+	b.ResetTimer()
+
+	for range keychain.DeriveRange(context.Background(), basePath, 0|slip10.Hardened, uint32(b.N)|slip10.Hardened) {
+	}
+}
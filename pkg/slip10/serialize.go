@@ -0,0 +1,225 @@
+package slip10
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/wollac/iota-crypto-demo/pkg/base58"
+	"github.com/wollac/iota-crypto-demo/pkg/bip32path"
+)
+
+// SerializedKeySize is the size, in bytes, of a serialized extended key before the Base58Check encoding.
+const SerializedKeySize = 4 + 1 + FingerprintSize + 4 + ChainCodeSize + PublicKeySize
+
+// Versions contains the version bytes used to serialize extended private and public keys.
+type Versions struct {
+	Private uint32
+	Public  uint32
+}
+
+// Version bytes as defined in BIP-32.
+var (
+	// Mainnet contains the versions of mainnet keys, which are serialized as "xprv" and "xpub".
+	Mainnet = Versions{Private: 0x0488ade4, Public: 0x0488b21e}
+	// Testnet contains the versions of testnet keys, which are serialized as "tprv" and "tpub".
+	Testnet = Versions{Private: 0x04358394, Public: 0x043587cf}
+)
+
+// Errors returned when parsing serialized extended keys.
+var (
+	ErrInvalidLength  = errors.New("invalid length")
+	ErrUnknownVersion = errors.New("unknown version")
+	ErrUnknownCurve   = errors.New("unknown curve")
+	ErrCurveMismatch  = errors.New("curve does not match versions")
+	ErrInvalidDepth   = errors.New("invalid depth")
+)
+
+// A PublicKeyParser is a Curve that can reconstruct public keys from their SLIP-10 serialization.
+type PublicKeyParser interface {
+	// NewPublicKey creates a public key from buf, which must have the format returned by Key.Bytes.
+	// When buf does not correspond to a valid public key, ErrInvalidKey is returned.
+	NewPublicKey(buf []byte) (Key, error)
+}
+
+type versionInfo struct {
+	curve   Curve
+	private bool
+}
+
+var versions = make(map[uint32]versionInfo)
+
+func init() {
+	// the standard versions are always known, but can only be parsed with an explicit curve
+	RegisterVersions(Mainnet, nil)
+	RegisterVersions(Testnet, nil)
+}
+
+// RegisterVersions registers the versions v to be used by the given curve.
+// This allows ExtendedKey.UnmarshalText to parse serialized keys with these versions without an explicit curve.
+// Keys of any other curve can no longer be serialized or parsed with these versions.
+// This is intended to be called from the init function in packages that implement curves.
+func RegisterVersions(v Versions, curve Curve) {
+	versions[v.Private] = versionInfo{curve, true}
+	versions[v.Public] = versionInfo{curve, false}
+}
+
+// Serialize returns the BIP-32 serialization of the extended key using the provided versions.
+// The result is the Base58Check encoding of the 78-byte serialization.
+// The versions must have been registered for the curve of the key, otherwise ErrCurveMismatch is returned.
+func (e *ExtendedKey) Serialize(v Versions) (string, error) {
+	version := v.Public
+	if e.IsPrivate() {
+		version = v.Private
+	}
+	info, ok := versions[version]
+	if !ok {
+		return "", ErrUnknownVersion
+	}
+	if info.curve == nil || e.curve == nil || info.curve.Name() != e.curve.Name() {
+		return "", fmt.Errorf("%w: version %x cannot be used for %s keys", ErrCurveMismatch, uint32Bytes(version), curveName(e.curve))
+	}
+	if e.depth > 0xff {
+		return "", fmt.Errorf("%w: depth %d exceeds maximum", ErrInvalidDepth, e.depth)
+	}
+
+	buf := make([]byte, 0, SerializedKeySize)
+	buf = append(buf, uint32Bytes(version)...)
+	buf = append(buf, byte(e.depth))
+	buf = append(buf, e.Fingerprint()...)
+	buf = append(buf, uint32Bytes(e.childNumber)...)
+	buf = append(buf, e.ChainCode...)
+	// private keys are prefixed with 0x00 to match the length of public keys
+	if e.IsPrivate() {
+		buf = append(buf, 0x00)
+	}
+	buf = append(buf, e.Key.Bytes()...)
+
+	if len(buf) != SerializedKeySize {
+		return "", fmt.Errorf("%w: invalid key size", ErrInvalidLength)
+	}
+	return base58.CheckEncode(buf), nil
+}
+
+// String returns the BIP-32 serialization of the extended key using the Mainnet versions.
+// As these versions are registered for secp256k1, keys of all other curves are formatted as an error.
+func (e *ExtendedKey) String() string {
+	s, err := e.Serialize(Mainnet)
+	if err != nil {
+		return "%!(" + err.Error() + ")"
+	}
+	return s
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The encoding is the BIP-32 serialization using the Mainnet versions, which is only possible for secp256k1 keys.
+func (e *ExtendedKey) MarshalText() ([]byte, error) {
+	s, err := e.Serialize(Mainnet)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// The key is expected in the BIP-32 serialization with versions that have been registered for a curve.
+func (e *ExtendedKey) UnmarshalText(text []byte) error {
+	buf, err := base58.CheckDecode(string(text))
+	if err != nil {
+		return err
+	}
+	if len(buf) != SerializedKeySize {
+		return fmt.Errorf("%w: serialized key must be %d bytes", ErrInvalidLength, SerializedKeySize)
+	}
+	info, ok := versions[binary.BigEndian.Uint32(buf)]
+	if !ok {
+		return ErrUnknownVersion
+	}
+	if info.curve == nil {
+		return fmt.Errorf("%w: no curve registered for version %x", ErrUnknownCurve, buf[:4])
+	}
+	x, err := deserialize(buf, info.curve)
+	if err != nil {
+		return err
+	}
+	*e = *x
+	return nil
+}
+
+// ParseExtendedKey parses s as a BIP-32 serialized extended key for the given curve.
+// The version of s must either match Mainnet, Testnet or any other registered versions.
+// If the version has been registered for a different curve, ErrCurveMismatch is returned.
+// To parse an extended public key, the curve must implement PublicKeyParser.
+func ParseExtendedKey(s string, curve Curve) (*ExtendedKey, error) {
+	buf, err := base58.CheckDecode(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) != SerializedKeySize {
+		return nil, fmt.Errorf("%w: serialized key must be %d bytes", ErrInvalidLength, SerializedKeySize)
+	}
+	return deserialize(buf, curve)
+}
+
+func deserialize(buf []byte, curve Curve) (*ExtendedKey, error) {
+	info, ok := versions[binary.BigEndian.Uint32(buf)]
+	if !ok {
+		return nil, ErrUnknownVersion
+	}
+	if info.curve != nil && info.curve.Name() != curve.Name() {
+		return nil, fmt.Errorf("%w: version %x cannot be used for %s keys", ErrCurveMismatch, buf[:4], curve.Name())
+	}
+
+	depth := int(buf[4])
+	fingerprint := buf[5 : 5+FingerprintSize]
+	childNumber := binary.BigEndian.Uint32(buf[9:13])
+	chainCode := buf[13 : 13+ChainCodeSize]
+	keyData := buf[13+ChainCodeSize:]
+
+	// the master key must neither have a parent fingerprint nor a child number
+	if depth == 0 && (childNumber != 0 || binary.BigEndian.Uint32(fingerprint) != 0) {
+		return nil, fmt.Errorf("%w: zero depth with non-zero parent fingerprint or index", ErrInvalidDepth)
+	}
+
+	var (
+		key Key
+		err error
+	)
+	if info.private {
+		if keyData[0] != 0x00 {
+			return nil, fmt.Errorf("%w: private key must be prefixed with 0x00", ErrInvalidKey)
+		}
+		key, err = curve.NewPrivateKey(keyData[1:])
+	} else {
+		parser, ok := curve.(PublicKeyParser)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s does not support parsing public keys", ErrUnknownCurve, curve.Name())
+		}
+		key, err = parser.NewPublicKey(keyData)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s key: %w", curve.Name(), err)
+	}
+
+	e := &ExtendedKey{
+		ChainCode:   append([]byte{}, chainCode...),
+		Key:         key,
+		curve:       curve,
+		depth:       depth,
+		childNumber: childNumber,
+	}
+	if depth > 0 {
+		e.parentFingerprint = append([]byte{}, fingerprint...)
+	} else {
+		// the path of the master key is always known
+		e.path = bip32path.Path{}
+	}
+	return e, nil
+}
+
+func curveName(curve Curve) string {
+	if curve == nil {
+		return "unknown"
+	}
+	return curve.Name()
+}
@@ -6,10 +6,30 @@ following curves:
 	secp256k1 curve
 	NIST P-256 curve
 	ed25519 curve
+	curve25519 curve (X25519)
+
+Additionally, BIP32-Ed25519 is supported for the ed25519 curve. It provides
+non-hardened derivation using keys that implement ChildDeriver. Master keys
+following different generation schemes, e.g. Icarus, can be created using
+NewExtendedKey.
 
 The public key of an SLIP-0010 extended private key can be computed using
 Curve.Public.
 
+Extended keys can be serialized in the Base58Check format described in BIP-0032
+using ExtendedKey.Serialize and parsed again using ParseExtendedKey. The
+Mainnet and Testnet versions are reserved for secp256k1; keys of other curves
+can only be serialized with versions registered for them using RegisterVersions.
+
+Private key material can be overwritten with zeros using ExtendedKey.Wipe,
+which is supported by all keys implementing Wiper.
+
+To derive many keys sharing a common path prefix, Keychain caches the
+intermediate nodes and supports parallel derivation using Keychain.DeriveRange.
+
+Symmetric keys can be derived from the same seed using the hierarchical scheme
+described in SLIP-0021, see NewSymmetricMasterNode.
+
 SLIP-0010 provides an extension of BIP-0032. As such, when the secp256k1 curve
 is selected this package is fully compatible to the corresponding derivations
 described in BIP-0032.
@@ -28,6 +48,8 @@ import (
 	"fmt"
 	"hash"
 
+	"github.com/wollac/iota-crypto-demo/internal/wipe"
+	"github.com/wollac/iota-crypto-demo/pkg/bip32path"
 	"golang.org/x/crypto/ripemd160" //nolint:staticcheck,deprecated
 )
 
@@ -48,6 +70,9 @@ const (
 // ErrInvalidKey is returned when the input led to an invalid private or public key.
 var ErrInvalidKey = errors.New("invalid key")
 
+// ErrInvalidPath is returned when a derivation path does not match the extended key.
+var ErrInvalidPath = errors.New("invalid path")
+
 // ErrHardenedChildPublicKey is returned when ExtendedKey.DeriveChild is called with a hardened index on a public key.
 var ErrHardenedChildPublicKey = errors.New("cannot create hardened child from public parent key")
 
@@ -56,7 +81,11 @@ type ExtendedKey struct {
 	ChainCode []byte
 	Key       Key
 
-	parent Key // the parent key needed for the fingerprint computation
+	curve             Curve          // the curve of the key, nil if unknown
+	depth             int            // number of derivations from the master key
+	childNumber       uint32         // index of the key in its parent's derivation
+	path              bip32path.Path // the derivation path from the master key, nil if unknown
+	parentFingerprint []byte         // the fingerprint of the parent's key, nil for the master key
 }
 
 // A Curve represents a curve type to derive private and public key pairs for.
@@ -76,7 +105,8 @@ type Curve interface {
 // A Key represents a private or public key for a curve.
 type Key interface {
 	// Bytes serializes the key as a byte slice.
-	// The number of bytes must match PrivateKeySize or PublicKeySize respectively.
+	// The number of bytes must match PrivateKeySize or PublicKeySize respectively, unless the key implements
+	// ChildDeriver; such keys can, however, not be serialized in the BIP-32 format.
 	Bytes() []byte
 
 	// IsPrivate returns whether the key corresponds to a private or public key.
@@ -92,6 +122,28 @@ type Key interface {
 	Shift([]byte) (Key, error)
 }
 
+// A ChildDeriver is a Key that implements its own child key derivation instead of the one described in SLIP-10.
+// This is required for schemes like BIP32-Ed25519 that use a different derivation function.
+type ChildDeriver interface {
+	// DeriveChild derives the child key and its chain code from the key and the chain code of the parent.
+	// For public keys, it is only called with non-hardened indices.
+	DeriveChild(chainCode []byte, index uint32) (Key, []byte, error)
+}
+
+// A Wiper is a Key that can overwrite its secret material with zeros.
+// All private keys of the curves in this module implement Wiper.
+type Wiper interface {
+	// Wipe zeroes the key material. The key must not be used afterwards.
+	Wipe()
+}
+
+// A Cloner is a Key that can create an independent copy of itself, which can be wiped without affecting the original.
+// All private keys of the curves in this module implement Cloner.
+type Cloner interface {
+	// Clone returns a copy of the key that does not share any memory with the original.
+	Clone() Key
+}
+
 // NewMasterKey creates a new master private extended key for the curve from a seed.
 func NewMasterKey(seed []byte, curve Curve) (*ExtendedKey, error) {
 	inter := make([]byte, 0, 64)
@@ -115,6 +167,8 @@ step1:
 		seed = inter
 		goto step1
 	}
+	// the key must not alias I_L, so that no copy of the secret remains
+	wipe.Bytes(left)
 
 	// use I_R as chain code
 	chainCode := right
@@ -122,41 +176,88 @@ step1:
 	return &ExtendedKey{
 		ChainCode: chainCode,
 		Key:       key,
-		parent:    nil,
+		curve:     curve,
+		path:      bip32path.Path{},
 	}, nil
 }
 
+// NewExtendedKey creates a new master extended key from a key and chain code that have been generated externally.
+// This allows the usage of master key generation schemes different from the one described in SLIP-10.
+// The curve must be the one the key belongs to, as it determines the versions used for serialization.
+func NewExtendedKey(key Key, chainCode []byte, curve Curve) *ExtendedKey {
+	return &ExtendedKey{
+		ChainCode: chainCode,
+		Key:       key,
+		curve:     curve,
+		path:      bip32path.Path{},
+	}
+}
+
 // DeriveKeyFromPath derives an extended private key for the curve from seed and path as outlined by SLIP-10.
+// All intermediate private keys are wiped.
 func DeriveKeyFromPath(seed []byte, curve Curve, path []uint32) (*ExtendedKey, error) {
 	key, err := NewMasterKey(seed, curve)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate master key: %w", err)
 	}
 	for _, childIndex := range path {
-		key, err = key.DeriveChild(childIndex)
+		child, err := key.DeriveChild(childIndex)
 		if err != nil {
+			key.Wipe()
 			return nil, fmt.Errorf("failed to derive child key: %w", err)
 		}
+		// the intermediate keys are not returned, so they can be wiped
+		key.Wipe()
+		key = child
 	}
 	return key, nil
 }
 
 // DeriveChild derives an extended key from a given parent extended key as outlined by SLIP-10.
 // If the parent is an extended public key, the child will also be an extended public key.
+// If the parent's key implements ChildDeriver, its derivation is used instead.
 func (e *ExtendedKey) DeriveChild(index uint32) (*ExtendedKey, error) {
+	// Check whether i ≥ 2³¹ (whether the child is a Hardened key)
+	// CKDpub is only defined for non-Hardened child keys
+	if index >= Hardened && !e.IsPrivate() {
+		return nil, ErrHardenedChildPublicKey
+	}
+
+	var (
+		childKey  Key
+		chainCode []byte
+		err       error
+	)
+	if d, ok := e.Key.(ChildDeriver); ok {
+		childKey, chainCode, err = d.DeriveChild(e.ChainCode, index)
+	} else {
+		childKey, chainCode, err = e.deriveChild(index)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive the child key: %w", err)
+	}
+
+	return &ExtendedKey{
+		ChainCode:   chainCode,
+		Key:         childKey,
+		curve:       e.curve,
+		depth:       e.depth + 1,
+		childNumber: index,
+		path:        e.childPath(index),
+		// the fingerprint is computed now, so that the child does not depend on the parent's key
+		parentFingerprint: e.KeyFingerprint(),
+	}, nil
+}
+
+// deriveChild derives the child key and chain code as outlined by SLIP-10.
+func (e *ExtendedKey) deriveChild(index uint32) (Key, []byte, error) {
 	inter := make([]byte, 0, 64)
 
-	// Check whether i ≥ 2³¹ (whether the child is a Hardened key)
 	if index >= Hardened {
-		// CKDpub is only defined for non-Hardened child keys
-		if !e.IsPrivate() {
-			return nil, ErrHardenedChildPublicKey
-		}
-
 		// I ← HMAC-SHA512(Key = chain_par, Data = 0x00 || ser256(key_par) || ser32(index))
 		h, err := hmacSHA512(e.ChainCode, []byte{0x00}, e.Key.Bytes(), uint32Bytes(index))
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		inter = h.Sum(inter[:0])
 	} else {
@@ -164,7 +265,7 @@ func (e *ExtendedKey) DeriveChild(index uint32) (*ExtendedKey, error) {
 		// where public_par = key_par if par is a public key, or public_par = point(key_par) otherwise
 		h, err := hmacSHA512(e.ChainCode, e.Key.Public().Bytes(), uint32Bytes(index))
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		inter = h.Sum(inter[:0])
 	}
@@ -181,24 +282,21 @@ step2:
 		// Set I ← HMAC-SHA512(Key = chain_par, Data = 0x01 || I_R || ser32(index)) and restart at step 2
 		h, err := hmacSHA512(e.ChainCode, []byte{0x01}, right, uint32Bytes(index))
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		inter = h.Sum(inter[:0])
 
 		goto step2
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to derive the child key: %w", err)
+		wipe.Bytes(left)
+		return nil, nil, err
 	}
+	// the child key must not alias I_L, so that no copy of the shift remains
+	wipe.Bytes(left)
 
 	// The returned chain code is I_R
-	chainCode := right
-
-	return &ExtendedKey{
-		ChainCode: chainCode,
-		Key:       childKey,
-		parent:    e.Key,
-	}, nil
+	return childKey, right, nil
 }
 
 // IsPrivate returns whether the key is an extended private key or extended public key.
@@ -210,19 +308,97 @@ func (e *ExtendedKey) IsPrivate() bool {
 // If key is already an extended public key, a copy is returned.
 func (e *ExtendedKey) Public() *ExtendedKey {
 	return &ExtendedKey{
-		ChainCode: e.ChainCode,
-		Key:       e.Key.Public(),
-		parent:    e.parent,
+		ChainCode:         append([]byte{}, e.ChainCode...),
+		Key:               e.Key.Public(),
+		curve:             e.curve,
+		depth:             e.depth,
+		childNumber:       e.childNumber,
+		path:              e.path,
+		parentFingerprint: e.parentFingerprint,
+	}
+}
+
+// clone returns a copy of e that can be wiped without affecting e.
+// If the key does not implement Cloner, it is shared between both extended keys.
+func (e *ExtendedKey) clone() *ExtendedKey {
+	x := *e
+	x.ChainCode = append([]byte{}, e.ChainCode...)
+	if c, ok := e.Key.(Cloner); ok {
+		x.Key = c.Clone()
 	}
+	return &x
+}
+
+// Wipe overwrites the chain code and, if the key implements Wiper, the key material with zeros.
+// The extended key must not be used afterwards. Keys derived from e are not affected.
+func (e *ExtendedKey) Wipe() {
+	wipe.Bytes(e.ChainCode)
+	if w, ok := e.Key.(Wiper); ok {
+		w.Wipe()
+	}
+}
+
+// Depth returns the number of derivations that lead from the master key to the key.
+// The master key has depth zero.
+func (e *ExtendedKey) Depth() int {
+	return e.depth
+}
+
+// ChildNumber returns the index that was used to derive the key from its parent.
+// For the master key zero is returned.
+func (e *ExtendedKey) ChildNumber() uint32 {
+	return e.childNumber
+}
+
+// Path returns the derivation path from the master key to the key.
+// The path is only known, if the key has been derived from a master key or if it has been set using WithPath.
+// Otherwise, nil is returned.
+func (e *ExtendedKey) Path() bip32path.Path {
+	if e.path == nil {
+		return nil
+	}
+	return append(bip32path.Path{}, e.path...)
+}
+
+// WithPath returns a copy of the key with its derivation path set to path.
+// This can be used to provide the origin of keys that have not been derived from a master key, e.g. parsed keys.
+// The path must be consistent with the depth and the child number of the key.
+func (e *ExtendedKey) WithPath(path bip32path.Path) (*ExtendedKey, error) {
+	if len(path) != e.depth {
+		return nil, fmt.Errorf("%w: path length %d does not match depth %d", ErrInvalidPath, len(path), e.depth)
+	}
+	if len(path) > 0 && path[len(path)-1] != e.childNumber {
+		return nil, fmt.Errorf("%w: last index %d does not match child number %d", ErrInvalidPath, path[len(path)-1], e.childNumber)
+	}
+
+	x := *e
+	x.path = append(bip32path.Path{}, path...)
+	return &x, nil
+}
+
+// childPath returns the path of the child with the given index, or nil if the path of e is unknown.
+func (e *ExtendedKey) childPath(index uint32) bip32path.Path {
+	if e.path == nil {
+		return nil
+	}
+	path := make(bip32path.Path, len(e.path)+1)
+	copy(path, e.path)
+	path[len(e.path)] = index
+	return path
 }
 
 // Fingerprint returns the fingerprint of the parent's key.
 func (e *ExtendedKey) Fingerprint() []byte {
-	if e.parent == nil {
+	if e.parentFingerprint == nil {
 		return make([]byte, FingerprintSize)
 	}
-	parentBytes := e.parent.Public().Bytes()
-	return hash160(parentBytes)[:FingerprintSize]
+	return append([]byte{}, e.parentFingerprint...)
+}
+
+// KeyFingerprint returns the fingerprint of the key itself.
+// It corresponds to the value returned by Fingerprint for all children of the key.
+func (e *ExtendedKey) KeyFingerprint() []byte {
+	return hash160(e.Key.Public().Bytes())[:FingerprintSize]
 }
 
 func uint32Bytes(i uint32) []byte {
@@ -1,10 +1,12 @@
 package slip10_test
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	cryptorand "crypto/rand"
 	"encoding/hex"
 	"encoding/json"
+	"math/big"
 	"math/rand"
 	"os"
 	"path/filepath"
@@ -14,9 +16,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/wollac/iota-crypto-demo/internal/hexutil"
+	"github.com/wollac/iota-crypto-demo/pkg/base58"
+	"github.com/wollac/iota-crypto-demo/pkg/bech32"
 	"github.com/wollac/iota-crypto-demo/pkg/bip32path"
+	"github.com/wollac/iota-crypto-demo/pkg/bip39"
 	"github.com/wollac/iota-crypto-demo/pkg/ed25519"
 	"github.com/wollac/iota-crypto-demo/pkg/slip10"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/ecdh"
 	"github.com/wollac/iota-crypto-demo/pkg/slip10/eddsa"
 	"github.com/wollac/iota-crypto-demo/pkg/slip10/elliptic"
 )
@@ -49,6 +55,11 @@ func TestEd25519(t *testing.T) {
 	runCurveTests(t, eddsa.Ed25519(), tvs)
 }
 
+func TestCurve25519(t *testing.T) {
+	tvs := readJSONTests(t)
+	runCurveTests(t, ecdh.X25519(), tvs)
+}
+
 func TestNist256p1Retry(t *testing.T) {
 	tvs := readJSONTests(t)
 	runCurveTests(t, elliptic.Nist256p1(), tvs)
@@ -105,6 +116,450 @@ func TestEd25519PublicDerivation(t *testing.T) {
 	require.ErrorIs(t, err, eddsa.ErrNotHardened)
 }
 
+type SerializationTest struct {
+	Path bip32path.Path `json:"chain"`
+	Xprv string         `json:"xprv"`
+	Xpub string         `json:"xpub"`
+}
+
+func TestSerialization(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("testdata", t.Name()+".json"))
+	require.NoError(t, err)
+
+	var tvs []struct {
+		Seed  hexutil.Bytes       `json:"seed"`
+		Tests []SerializationTest `json:"tests"`
+	}
+	require.NoError(t, json.Unmarshal(b, &tvs))
+
+	curve := elliptic.Secp256k1()
+	for _, tv := range tvs {
+		for _, tt := range tv.Tests {
+			t.Run(strings.ReplaceAll(tt.Path.String(), "/", "|"), func(t *testing.T) {
+				privateKey, err := slip10.DeriveKeyFromPath(tv.Seed, curve, tt.Path)
+				require.NoError(t, err)
+				assert.Equal(t, tt.Xprv, privateKey.String())
+				assert.Equal(t, tt.Xpub, privateKey.Public().String())
+
+				parsed, err := slip10.ParseExtendedKey(tt.Xprv, curve)
+				require.NoError(t, err)
+				assert.Equal(t, tt.Xprv, parsed.String())
+				assert.True(t, parsed.IsPrivate())
+				assert.EqualValues(t, privateKey.ChainCode, parsed.ChainCode)
+				assert.EqualValues(t, privateKey.Key.Bytes(), parsed.Key.Bytes())
+				assert.EqualValues(t, privateKey.Fingerprint(), parsed.Fingerprint())
+
+				var publicKey slip10.ExtendedKey
+				require.NoError(t, publicKey.UnmarshalText([]byte(tt.Xpub)))
+				assert.Equal(t, tt.Xpub, publicKey.String())
+				assert.False(t, publicKey.IsPrivate())
+				assert.EqualValues(t, privateKey.Key.Public().Bytes(), publicKey.Key.Bytes())
+
+				// the parsed keys must be usable for further derivation
+				privateChild, err := parsed.DeriveChild(0)
+				require.NoError(t, err)
+				publicChild, err := publicKey.DeriveChild(0)
+				require.NoError(t, err)
+				assert.Equal(t, privateChild.Public().String(), publicChild.String())
+			})
+		}
+	}
+}
+
+func TestWithPath(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	path := bip32path.Path{0 | slip10.Hardened, 1, 2 | slip10.Hardened}
+	key, err := slip10.DeriveKeyFromPath(seed, elliptic.Secp256k1(), path)
+	require.NoError(t, err)
+
+	// the path is not part of the serialization
+	parsed, err := slip10.ParseExtendedKey(key.Public().String(), elliptic.Secp256k1())
+	require.NoError(t, err)
+	assert.Nil(t, parsed.Path())
+	assert.Equal(t, len(path), parsed.Depth())
+	assert.Equal(t, path[len(path)-1], parsed.ChildNumber())
+
+	child, err := parsed.DeriveChild(3)
+	require.NoError(t, err)
+	assert.Nil(t, child.Path())
+	assert.Equal(t, len(path)+1, child.Depth())
+
+	withPath, err := parsed.WithPath(path)
+	require.NoError(t, err)
+	assert.Nil(t, parsed.Path())
+	assertOrigin(t, path, withPath)
+
+	child, err = withPath.DeriveChild(3)
+	require.NoError(t, err)
+	assertOrigin(t, append(path, 3), child)
+
+	_, err = parsed.WithPath(path[:2])
+	assert.ErrorIs(t, err, slip10.ErrInvalidPath)
+	_, err = parsed.WithPath(bip32path.Path{0, 1, 2})
+	assert.ErrorIs(t, err, slip10.ErrInvalidPath)
+}
+
+// testVersions are arbitrary versions that are only registered for the curves used in the serialization tests.
+var testVersions = map[string]slip10.Versions{
+	"nist256p1": {Private: 0x0488ade5, Public: 0x0488b21f},
+	"ed25519":   {Private: 0x0488ade6, Public: 0x0488b220},
+}
+
+func init() {
+	slip10.RegisterVersions(testVersions["nist256p1"], elliptic.Nist256p1())
+	slip10.RegisterVersions(testVersions["ed25519"], eddsa.Ed25519())
+}
+
+func TestSerializationNist256p1(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	curve := elliptic.Nist256p1()
+	key, err := slip10.DeriveKeyFromPath(seed, curve, []uint32{0 | slip10.Hardened, 1})
+	require.NoError(t, err)
+
+	for _, key := range []*slip10.ExtendedKey{key, key.Public()} {
+		s, err := key.Serialize(testVersions["nist256p1"])
+		require.NoError(t, err)
+
+		parsed, err := slip10.ParseExtendedKey(s, curve)
+		require.NoError(t, err)
+		assert.Equal(t, key.IsPrivate(), parsed.IsPrivate())
+		assert.EqualValues(t, key.ChainCode, parsed.ChainCode)
+		assert.EqualValues(t, key.Key.Bytes(), parsed.Key.Bytes())
+		assert.EqualValues(t, key.Fingerprint(), parsed.Fingerprint())
+
+		var unmarshaled slip10.ExtendedKey
+		require.NoError(t, unmarshaled.UnmarshalText([]byte(s)))
+		assert.EqualValues(t, key.Key.Bytes(), unmarshaled.Key.Bytes())
+	}
+}
+
+func TestSerializationEd25519(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	curve := eddsa.Ed25519()
+	key, err := slip10.DeriveKeyFromPath(seed, curve, []uint32{0 | slip10.Hardened, 1 | slip10.Hardened})
+	require.NoError(t, err)
+
+	for _, key := range []*slip10.ExtendedKey{key, key.Public()} {
+		s, err := key.Serialize(testVersions["ed25519"])
+		require.NoError(t, err)
+
+		parsed, err := slip10.ParseExtendedKey(s, curve)
+		require.NoError(t, err)
+		assert.EqualValues(t, key.Key.Bytes(), parsed.Key.Bytes())
+		assert.EqualValues(t, key.ChainCode, parsed.ChainCode)
+		assert.EqualValues(t, key.Fingerprint(), parsed.Fingerprint())
+	}
+}
+
+func TestSerializationCurveMismatch(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	var tests = []*struct {
+		name     string
+		curve    slip10.Curve
+		versions slip10.Versions
+	}{
+		{"nist256p1/mainnet", elliptic.Nist256p1(), slip10.Mainnet},
+		{"nist256p1/testnet", elliptic.Nist256p1(), slip10.Testnet},
+		{"ed25519/mainnet", eddsa.Ed25519(), slip10.Mainnet},
+		{"curve25519/mainnet", ecdh.X25519(), slip10.Mainnet},
+		{"ed25519-bip32/mainnet", eddsa.BIP32Ed25519(), slip10.Mainnet},
+		{"secp256k1/ed25519", elliptic.Secp256k1(), testVersions["ed25519"]},
+		{"ed25519/nist256p1", eddsa.Ed25519(), testVersions["nist256p1"]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := slip10.DeriveKeyFromPath(seed, tt.curve, []uint32{0 | slip10.Hardened})
+			require.NoError(t, err)
+
+			for _, key := range []*slip10.ExtendedKey{key, key.Public()} {
+				_, err = key.Serialize(tt.versions)
+				assert.ErrorIs(t, err, slip10.ErrCurveMismatch)
+			}
+			if tt.versions == slip10.Mainnet {
+				_, err = key.MarshalText()
+				assert.ErrorIs(t, err, slip10.ErrCurveMismatch)
+				assert.Contains(t, key.String(), slip10.ErrCurveMismatch.Error())
+			}
+		})
+	}
+
+	// a secp256k1 key cannot be parsed as a key of a different curve
+	key, err := slip10.DeriveKeyFromPath(seed, elliptic.Secp256k1(), []uint32{0 | slip10.Hardened})
+	require.NoError(t, err)
+	_, err = slip10.ParseExtendedKey(key.String(), elliptic.Nist256p1())
+	assert.ErrorIs(t, err, slip10.ErrCurveMismatch)
+}
+
+func TestParseExtendedKeyInvalid(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	curve := elliptic.Secp256k1()
+	key, err := slip10.DeriveKeyFromPath(seed, curve, []uint32{0 | slip10.Hardened})
+	require.NoError(t, err)
+
+	xprv, _ := base58.CheckDecode(key.String())
+	xpub, _ := base58.CheckDecode(key.Public().String())
+	modify := func(buf []byte, f func([]byte)) string {
+		buf = append([]byte{}, buf...)
+		f(buf)
+		return base58.CheckEncode(buf)
+	}
+
+	var tests = []*struct {
+		name string
+		s    string
+		err  error
+	}{
+		{"bad checksum", key.String()[:len(key.String())-1] + "1", base58.ErrInvalidChecksum},
+		{"invalid length", base58.CheckEncode(xprv[:slip10.SerializedKeySize-1]), slip10.ErrInvalidLength},
+		{"unknown version", modify(xprv, func(b []byte) { b[3] = 0xff }), slip10.ErrUnknownVersion},
+		{"private key prefix", modify(xprv, func(b []byte) { b[45] = 0x01 }), slip10.ErrInvalidKey},
+		{"private key zero", modify(xprv, func(b []byte) { copy(b[46:], make([]byte, 32)) }), slip10.ErrInvalidKey},
+		{"private key overflow", modify(xprv, func(b []byte) { copy(b[46:], bytes.Repeat([]byte{0xff}, 32)) }), slip10.ErrInvalidKey},
+		{"public key prefix", modify(xpub, func(b []byte) { b[45] = 0x04 }), slip10.ErrInvalidKey},
+		{"public key not on curve", modify(xpub, func(b []byte) { copy(b[46:], make([]byte, 32)) }), slip10.ErrInvalidKey},
+		{"private version with public key", modify(xpub, func(b []byte) { copy(b, xprv[:4]) }), slip10.ErrInvalidKey},
+		{"zero depth with fingerprint", modify(xprv, func(b []byte) { b[4] = 0 }), slip10.ErrInvalidDepth},
+		{"zero depth with index", modify(xprv, func(b []byte) { b[4] = 0; copy(b[5:9], make([]byte, 4)) }), slip10.ErrInvalidDepth},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := slip10.ParseExtendedKey(tt.s, curve)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestBIP32Ed25519Key(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	masterKey, err := slip10.NewMasterKey(seed, eddsa.BIP32Ed25519())
+	require.NoError(t, err)
+
+	// the master public key matches the one of Ed25519
+	ed25519Key, err := slip10.NewMasterKey(seed, eddsa.Ed25519())
+	require.NoError(t, err)
+	require.EqualValues(t, ed25519Key.Key.Public().Bytes(), masterKey.Key.Public().Bytes())
+
+	parentKey, err := masterKey.DeriveChild(0 | slip10.Hardened)
+	require.NoError(t, err)
+
+	message := []byte("test message")
+	for index := uint32(0); index < 100; index++ {
+		privateKey, err := parentKey.DeriveChild(index)
+		require.NoError(t, err)
+		require.True(t, privateKey.IsPrivate())
+		publicKey, err := parentKey.Public().DeriveChild(index)
+		require.NoError(t, err)
+		require.False(t, publicKey.IsPrivate())
+		require.Equal(t, privateKey.Public().Key.Bytes(), publicKey.Key.Bytes())
+		require.Equal(t, privateKey.ChainCode, publicKey.ChainCode)
+
+		// sign with the private extended key
+		sig := privateKey.Key.(eddsa.ExtendedPrivateKey).Sign(message)
+
+		// verify with the public extended key
+		pub := publicKey.Key.(eddsa.ExtendedPublicKey).Ed25519PublicKey()
+		require.Truef(t, ed25519.Verify(pub, message, sig), "verify failed")
+	}
+
+	_, err = parentKey.Public().DeriveChild(0 | slip10.Hardened)
+	require.ErrorIs(t, err, slip10.ErrHardenedChildPublicKey)
+}
+
+func TestBIP32Ed25519Vectors(t *testing.T) {
+	// test vector from CIP-0019 using the Icarus master key of CIP-0003
+	const (
+		mnemonic = "test walk nut penalty hip pave soap entry language right filter choice"
+		account  = "1852'/1815'/0'"
+		address  = "0/0"
+		// Ed25519 public key of the payment key
+		paymentKey = "addr_vk1w0l2sr2zgfm26ztc6nl9xy8ghsk5sh6ldwemlpmp9xylzy4dtf7st80zhd"
+	)
+	_, expected, err := bech32.Decode(paymentKey)
+	require.NoError(t, err)
+
+	entropy, err := bip39.MnemonicToEntropy(bip39.ParseMnemonic(mnemonic))
+	require.NoError(t, err)
+	accountPath, err := bip32path.ParsePath(account)
+	require.NoError(t, err)
+	addressPath, err := bip32path.ParsePath(address)
+	require.NoError(t, err)
+
+	// hardened derivation of the account key
+	accountKey := eddsa.NewIcarusMasterKey(entropy, "")
+	for _, index := range accountPath {
+		accountKey, err = accountKey.DeriveChild(index)
+		require.NoError(t, err)
+	}
+
+	// non-hardened derivation using the private key
+	privateKey := accountKey
+	for _, index := range addressPath {
+		privateKey, err = privateKey.DeriveChild(index)
+		require.NoError(t, err)
+	}
+	assert.EqualValues(t, expected, privateKey.Key.(eddsa.ExtendedPrivateKey).Ed25519PublicKey())
+
+	// non-hardened derivation using the public key
+	publicKey := accountKey.Public()
+	for _, index := range addressPath {
+		publicKey, err = publicKey.DeriveChild(index)
+		require.NoError(t, err)
+	}
+	assert.EqualValues(t, expected, publicKey.Key.(eddsa.ExtendedPublicKey).Ed25519PublicKey())
+}
+
+func TestIcarusMasterKey(t *testing.T) {
+	// test vectors from CIP-0003
+	var tests = []*struct {
+		mnemonic   string
+		passphrase string
+		key        string
+	}{
+		{
+			"eight country switch draw meat scout mystery blade tip drift useless good keep usage title",
+			"",
+			"c065afd2832cd8b087c4d9ab7011f481ee1e0721e78ea5dd609f3ab3f156d245d176bd8fd4ec60b4731c3918a2a72a0226c0cd119ec35b47e4d55884667f552a23f7fdcd4a10c6cd2c7393ac61d877873e248f417634aa3d812af327ffe9d620",
+		},
+		{
+			"eight country switch draw meat scout mystery blade tip drift useless good keep usage title",
+			"foo",
+			"70531039904019351e1afb361cd1b312a4d0565d4ff9f8062d38acf4b15cce41d7b5738d9c893feea55512a3004acb0d222c35d3e3d5cde943a15a9824cbac59443cf67e589614076ba01e354b1a432e0e6db3b59e37fc56b5fb0222970a010e",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.passphrase, func(t *testing.T) {
+			entropy, err := bip39.MnemonicToEntropy(bip39.ParseMnemonic(tt.mnemonic))
+			require.NoError(t, err)
+
+			masterKey := eddsa.NewIcarusMasterKey(entropy, tt.passphrase)
+			assert.Equal(t, tt.key, hex.EncodeToString(append(masterKey.Key.Bytes(), masterKey.ChainCode...)))
+			assertOrigin(t, bip32path.Path{}, masterKey)
+
+			// the master key must support the BIP32-Ed25519 derivation
+			key, err := masterKey.DeriveChild(0 | slip10.Hardened)
+			require.NoError(t, err)
+			privateKey, err := key.DeriveChild(0)
+			require.NoError(t, err)
+			publicKey, err := key.Public().DeriveChild(0)
+			require.NoError(t, err)
+			assert.Equal(t, privateKey.Public().Key.Bytes(), publicKey.Key.Bytes())
+			assert.Equal(t, privateKey.ChainCode, publicKey.ChainCode)
+		})
+	}
+}
+
+func TestCardanoMasterKeyVectors(t *testing.T) {
+	// test vectors from SLIP-0023, kL is given as an integer in base 10
+	var tests = []*struct {
+		seed      string
+		kL        string
+		kR        string
+		publicKey string
+		chainCode string
+	}{
+		{
+			"578d685d20b602683dc5171df411d3e2",
+			"38096432269777187972282727382530464140043628323029465813805073381215192153792",
+			"4064253ffefc4127489bce1b825a47329010c5afb4d21154ef949ef786204405",
+			"83e3ecaf57f90f022c45e10d1b8cb78499c30819515ad9a81ad82139fdb12a90",
+			"22c12755afdd192742613b3062069390743ea232bc1b366c8f41e37292af9305",
+		},
+		{
+			"a055b781aac0c9dc1bfb7d803bc8ffd5d4392e506db2e4a5a93f0aba958c5be7",
+			"35870817594148037193235249761081259065186522922583196642112477624627719791504",
+			"f9d99bf3cd9c7e12663e8646afa40cb3aecf15d91f2abc15d21056c6bccb3414",
+			"eea170f0ef97b59d22907cb429888029721ed67d3e7a1b56b81731086ab7db64",
+			"04f1de750b62725fcc1ae1b93ca4063acb53c486b959cadaa100ebd7828e5460",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.seed, func(t *testing.T) {
+			seed, err := hex.DecodeString(tt.seed)
+			require.NoError(t, err)
+			kL, ok := new(big.Int).SetString(tt.kL, 10)
+			require.True(t, ok)
+
+			masterKey, err := slip10.NewMasterKey(seed, eddsa.Cardano())
+			require.NoError(t, err)
+			key := masterKey.Key.(eddsa.ExtendedPrivateKey)
+			// kL is encoded in little-endian
+			assert.Equal(t, kL.FillBytes(make([]byte, 32)), reverse(key.Bytes()[:32]))
+			assert.Equal(t, tt.kR, hex.EncodeToString(key.Bytes()[32:]))
+			assert.Equal(t, tt.publicKey, hex.EncodeToString(key.Ed25519PublicKey()))
+			assert.Equal(t, tt.chainCode, hex.EncodeToString(masterKey.ChainCode))
+		})
+	}
+}
+
+func TestCardanoMasterKey(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	masterKey, err := slip10.NewMasterKey(seed, eddsa.Cardano())
+	require.NoError(t, err)
+
+	// the chain code is I_R and kL must be clamped
+	key := masterKey.Key.Bytes()
+	assert.Len(t, key, ed25519.ExtendedPrivateKeySize)
+	assert.Zero(t, key[0]&0x07)
+	assert.Equal(t, byte(0x40), key[31]&0xe0)
+
+	privateKey, err := slip10.DeriveKeyFromPath(seed, eddsa.Cardano(), []uint32{1852 | slip10.Hardened, 1815 | slip10.Hardened, 0 | slip10.Hardened, 0, 0})
+	require.NoError(t, err)
+	publicKey, err := slip10.DeriveKeyFromPath(seed, eddsa.Cardano(), []uint32{1852 | slip10.Hardened, 1815 | slip10.Hardened, 0 | slip10.Hardened})
+	require.NoError(t, err)
+	publicKey, err = publicKey.Public().DeriveChild(0)
+	require.NoError(t, err)
+	publicKey, err = publicKey.DeriveChild(0)
+	require.NoError(t, err)
+	assert.Equal(t, privateKey.Public().Key.Bytes(), publicKey.Key.Bytes())
+	assert.Equal(t, privateKey.ChainCode, publicKey.ChainCode)
+}
+
+func TestX25519Key(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	alice, err := slip10.DeriveKeyFromPath(seed, ecdh.X25519(), []uint32{0 | slip10.Hardened})
+	require.NoError(t, err)
+	bob, err := slip10.DeriveKeyFromPath(seed, ecdh.X25519(), []uint32{1 | slip10.Hardened})
+	require.NoError(t, err)
+
+	alicePriv := alice.Key.(ecdh.PrivateKey)
+	bobPriv := bob.Key.(ecdh.PrivateKey)
+	s1, err := alicePriv.SharedSecret(bobPriv.X25519PublicKey())
+	require.NoError(t, err)
+	s2, err := bobPriv.SharedSecret(alicePriv.X25519PublicKey())
+	require.NoError(t, err)
+	assert.Equal(t, s1, s2)
+
+	_, err = alice.Public().DeriveChild(0 | slip10.Hardened)
+	require.ErrorIs(t, err, slip10.ErrHardenedChildPublicKey)
+	_, err = alice.Public().DeriveChild(0)
+	require.ErrorIs(t, err, ecdh.ErrNotHardened)
+}
+
+func TestSymmetricKey(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("testdata", t.Name()+".json"))
+	require.NoError(t, err)
+
+	var tvs []*struct {
+		Seed  hexutil.Bytes `json:"seed"`
+		Tests []*struct {
+			Labels []string      `json:"labels"`
+			Key    hexutil.Bytes `json:"key"`
+		} `json:"tests"`
+	}
+	require.NoError(t, json.Unmarshal(b, &tvs))
+
+	for _, tv := range tvs {
+		for _, tt := range tv.Tests {
+			node, err := slip10.DeriveSymmetricKeyFromPath(tv.Seed, tt.Labels...)
+			require.NoError(t, err)
+			t.Run(node.String(), func(t *testing.T) {
+				assert.EqualValues(t, tt.Key, node.Key())
+				assert.Equal(t, tt.Labels, node.Labels())
+			})
+		}
+	}
+}
+
 func readJSONTests(t *testing.T) []TestVector {
 	b, err := os.ReadFile(filepath.Join("testdata", t.Name()+".json"))
 	require.NoError(t, err)
@@ -134,6 +589,8 @@ func runTests(t *testing.T, seed []byte, curve slip10.Curve, tests []Test) {
 			assert.EqualValues(t, tt.Private, privateKey.Key.Bytes(), "unexpected private key")
 			assert.EqualValues(t, tt.Public, privateKey.Key.Public().Bytes(), "unexpected public key")
 			assert.True(t, privateKey.IsPrivate())
+			assertOrigin(t, tt.Path, privateKey)
+			assertOrigin(t, tt.Path, privateKey.Public())
 
 			// if the path is hardened, just check the corresponding public key
 			if len(tt.Path) == 0 || tt.Path[len(tt.Path)-1] >= slip10.Hardened {
@@ -160,10 +617,21 @@ func runTests(t *testing.T, seed []byte, curve slip10.Curve, tests []Test) {
 			assert.EqualValues(t, tt.ChainCode, publicKey.ChainCode, "unexpected chain code")
 			assert.EqualValues(t, tt.Public, publicKey.Key.Bytes(), "unexpected public key")
 			assert.False(t, publicKey.IsPrivate())
+			assertOrigin(t, tt.Path, publicKey)
 		})
 	}
 }
 
+func assertOrigin(t *testing.T, path bip32path.Path, key *slip10.ExtendedKey) {
+	assert.Equal(t, len(path), key.Depth(), "unexpected depth")
+	assert.Equal(t, path, key.Path(), "unexpected path")
+	if len(path) > 0 {
+		assert.Equal(t, path[len(path)-1], key.ChildNumber(), "unexpected child number")
+	} else {
+		assert.Zero(t, key.ChildNumber(), "unexpected child number")
+	}
+}
+
 func BenchmarkHardenedDerivation(b *testing.B) {
 	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
 	key, err := slip10.NewMasterKey(seed, elliptic.Nist256p1())
@@ -179,3 +647,11 @@ func BenchmarkHardenedDerivation(b *testing.B) {
 		key, _ = key.DeriveChild(index)
 	}
 }
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
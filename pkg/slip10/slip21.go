@@ -0,0 +1,85 @@
+package slip10
+
+import "strings"
+
+// SymmetricKeySize is the size, in bytes, of a symmetric key derived using SLIP-0021.
+const SymmetricKeySize = 32
+
+// symmetricKeySeed is the HMAC key used to generate the SLIP-0021 master node.
+var symmetricKeySeed = []byte("Symmetric key seed")
+
+// SymmetricNode represents a node in the SLIP-0021 hierarchical derivation of symmetric keys.
+// Each node consists of 64 bytes: the first half is used to derive the child nodes,
+// the second half is the symmetric key of the node.
+type SymmetricNode struct {
+	data   []byte
+	labels []string
+}
+
+// NewSymmetricMasterNode creates the SLIP-0021 master node from a seed.
+func NewSymmetricMasterNode(seed []byte) (*SymmetricNode, error) {
+	// Calculate S ← HMAC-SHA512(Key = "Symmetric key seed", Data = seed)
+	h, err := hmacSHA512(symmetricKeySeed, seed)
+	if err != nil {
+		return nil, err
+	}
+	return &SymmetricNode{
+		data:   h.Sum(nil),
+		labels: []string{},
+	}, nil
+}
+
+// DeriveSymmetricKeyFromPath derives the SLIP-0021 node for the given labels starting from the master node of seed.
+func DeriveSymmetricKeyFromPath(seed []byte, labels ...string) (*SymmetricNode, error) {
+	node, err := NewSymmetricMasterNode(seed)
+	if err != nil {
+		return nil, err
+	}
+	for _, label := range labels {
+		node, err = node.DeriveChild(label)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+// DeriveChild derives the child node with the given label.
+func (n *SymmetricNode) DeriveChild(label string) (*SymmetricNode, error) {
+	// Calculate N ← HMAC-SHA512(Key = N[0:32], Data = 0x00 || label)
+	h, err := hmacSHA512(n.data[:32], []byte{0x00}, []byte(label))
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make([]string, len(n.labels), len(n.labels)+1)
+	copy(labels, n.labels)
+	return &SymmetricNode{
+		data:   h.Sum(nil),
+		labels: append(labels, label),
+	}, nil
+}
+
+// Key returns the 32-byte symmetric key of the node.
+func (n *SymmetricNode) Key() []byte {
+	key := make([]byte, SymmetricKeySize)
+	copy(key, n.data[32:])
+	return key
+}
+
+// Labels returns the labels that have been used to derive the node from the master node.
+func (n *SymmetricNode) Labels() []string {
+	return append([]string{}, n.labels...)
+}
+
+// String returns the path of the node in the notation used by SLIP-0021, e.g. m/"SLIP-0021"/"Authentication key".
+func (n *SymmetricNode) String() string {
+	var b strings.Builder
+	b.WriteString("m")
+	for _, label := range n.labels {
+		b.WriteString(`/"`)
+		b.WriteString(label)
+		b.WriteString(`"`)
+	}
+	return b.String()
+}
@@ -0,0 +1,161 @@
+package slip10_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wollac/iota-crypto-demo/pkg/bip32path"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/ecdh"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/eddsa"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/elliptic"
+)
+
+// recordingCurve wraps a curve and records all buffers passed to NewPrivateKey and Shift.
+type recordingCurve struct {
+	slip10.Curve
+	invalid int // number of keys to reject
+	bufs    [][]byte
+	keys    []slip10.Key // all keys derived using Shift
+}
+
+func (c *recordingCurve) NewPrivateKey(buf []byte) (slip10.Key, error) {
+	c.bufs = append(c.bufs, buf)
+	if c.invalid > 0 {
+		c.invalid--
+		return nil, slip10.ErrInvalidKey
+	}
+	key, err := c.Curve.NewPrivateKey(buf)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingKey{key, c}, nil
+}
+
+type recordingKey struct {
+	slip10.Key
+	c *recordingCurve
+}
+
+func (k *recordingKey) Wipe() {
+	k.Key.(slip10.Wiper).Wipe()
+}
+
+func (k *recordingKey) Shift(buf []byte) (slip10.Key, error) {
+	k.c.bufs = append(k.c.bufs, buf)
+	key, err := k.Key.Shift(buf)
+	if err != nil {
+		return nil, err
+	}
+	k.c.keys = append(k.c.keys, key)
+	return &recordingKey{key, k.c}, nil
+}
+
+func assertZero(t *testing.T, b []byte) {
+	assert.Truef(t, bytes.Equal(b, make([]byte, len(b))), "buffer not zeroed: %x", b)
+}
+
+func TestWipeIntermediate(t *testing.T) {
+	curve := &recordingCurve{Curve: elliptic.Secp256k1(), invalid: 2}
+	path := bip32path.Path{44 | slip10.Hardened, 0 | slip10.Hardened, 0}
+
+	key, err := slip10.DeriveKeyFromPath(keychainSeed, curve, path)
+	require.NoError(t, err)
+
+	// one buffer per master key generation attempt and one per derivation
+	require.Len(t, curve.bufs, 3+len(path))
+	for _, buf := range curve.bufs {
+		assertZero(t, buf)
+	}
+
+	// the fingerprint must still be valid after the intermediate keys have been wiped
+	expected, err := slip10.DeriveKeyFromPath(keychainSeed, &recordingCurve{Curve: elliptic.Secp256k1(), invalid: 2}, path[:len(path)-1])
+	require.NoError(t, err)
+	expected, err = expected.DeriveChild(path[len(path)-1])
+	require.NoError(t, err)
+	assert.Equal(t, expected.Fingerprint(), key.Fingerprint())
+}
+
+func TestWipeParent(t *testing.T) {
+	// test vector 1 of BIP-32 for m/0H
+	const (
+		fingerprint = "3442193e"
+		xprv        = "xprv9uHRZZhk6KAJC1avXpDAp4MDc3sQKNxDiPvvkX8Br5ngLNv1TxvUxt4cV1rGL5hj6KCesnDYUhd7oWgT11eZG7XnxHrnYeSvkzY7d2bhkJ7"
+	)
+	master, err := slip10.NewMasterKey(keychainSeed, elliptic.Secp256k1())
+	require.NoError(t, err)
+	child, err := master.DeriveChild(0 | slip10.Hardened)
+	require.NoError(t, err)
+
+	// the child must neither depend on the key nor on the chain code of its parent
+	master.Wipe()
+	assert.Equal(t, fingerprint, hex.EncodeToString(child.Fingerprint()))
+	assert.Equal(t, xprv, child.String())
+}
+
+func TestExtendedKeyWipe(t *testing.T) {
+	var tests = []*struct {
+		name  string
+		curve slip10.Curve
+	}{
+		{"secp256k1", elliptic.Secp256k1()},
+		{"nist256p1", elliptic.Nist256p1()},
+		{"ed25519", eddsa.Ed25519()},
+		{"ed25519-bip32", eddsa.BIP32Ed25519()},
+		{"curve25519", ecdh.X25519()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := slip10.DeriveKeyFromPath(keychainSeed, tt.curve, bip32path.Path{0 | slip10.Hardened})
+			require.NoError(t, err)
+			public := key.Public()
+			publicBytes := append([]byte{}, public.Key.Bytes()...)
+			chainCodeBytes := append([]byte{}, public.ChainCode...)
+
+			chainCode := key.ChainCode
+			require.Implements(t, (*slip10.Wiper)(nil), key.Key)
+			key.Wipe()
+
+			assertZero(t, chainCode)
+			assertZero(t, key.Key.Bytes())
+			if k, ok := key.Key.(*elliptic.PrivateKey); ok {
+				assert.Zero(t, k.K.Sign())
+			}
+			// the public key must not be affected
+			assert.Equal(t, publicBytes, public.Key.Bytes())
+			assert.Equal(t, chainCodeBytes, public.ChainCode)
+		})
+	}
+}
+
+func TestKeychainWipe(t *testing.T) {
+	curve := &recordingCurve{Curve: eddsa.Ed25519()}
+	master, err := slip10.NewMasterKey(keychainSeed, curve)
+	require.NoError(t, err)
+	keychain := slip10.NewKeychain(master)
+
+	path := bip32path.Path{44 | slip10.Hardened, 4218 | slip10.Hardened, 0 | slip10.Hardened}
+	key, err := keychain.DeriveKey(path)
+	require.NoError(t, err)
+	require.Len(t, curve.keys, len(path))
+
+	masterBytes := append([]byte{}, master.Key.Bytes()...)
+	keyBytes := append([]byte{}, key.Key.Bytes()...)
+	keychain.Wipe()
+
+	// all cached intermediate nodes must be wiped
+	for _, k := range curve.keys[:len(path)-1] {
+		assertZero(t, k.Bytes())
+	}
+	// neither the master key nor the returned key must be affected
+	assert.Equal(t, masterBytes, master.Key.Bytes())
+	assert.Equal(t, keyBytes, key.Key.Bytes())
+
+	// the keychain must still derive the correct keys
+	key, err = keychain.DeriveKey(path)
+	require.NoError(t, err)
+	assert.Equal(t, keyBytes, key.Key.Bytes())
+}
@@ -0,0 +1,66 @@
+package slip39
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/wollac/iota-crypto-demo/internal/wipe"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// total number of PBKDF2 iterations of all rounds for an iteration exponent of 0
+	baseIterationCount = 10000
+	// number of rounds of the Feistel cipher
+	roundCount = 4
+)
+
+// encrypt encrypts the master secret with the passphrase using the 4-round Feistel network of SLIP-0039.
+func encrypt(masterSecret, passphrase []byte, iterationExponent int, identifier uint16, extendable bool) []byte {
+	salt := cipherSalt(identifier, extendable)
+	return feistel(masterSecret, passphrase, iterationExponent, salt, [roundCount]byte{0, 1, 2, 3})
+}
+
+// decrypt decrypts the encrypted master secret by applying the rounds of the Feistel network in reverse order.
+func decrypt(encryptedMasterSecret, passphrase []byte, iterationExponent int, identifier uint16,
+	extendable bool) []byte {
+	salt := cipherSalt(identifier, extendable)
+	return feistel(encryptedMasterSecret, passphrase, iterationExponent, salt, [roundCount]byte{3, 2, 1, 0})
+}
+
+func feistel(input, passphrase []byte, iterationExponent int, salt []byte, rounds [roundCount]byte) []byte {
+	half := len(input) / 2
+	l := append([]byte{}, input[:half]...)
+	r := append([]byte{}, input[half:]...)
+	iterations := (baseIterationCount << iterationExponent) / roundCount
+
+	password := make([]byte, 1+len(passphrase))
+	copy(password[1:], passphrase)
+	defer wipe.Bytes(password)
+	roundSalt := append(salt[:len(salt):len(salt)], r...)
+
+	for _, i := range rounds {
+		password[0] = i
+		copy(roundSalt[len(salt):], r)
+		f := pbkdf2.Key(password, roundSalt, iterations, len(r), sha256.New)
+		for j := range f {
+			l[j] ^= f[j]
+		}
+		l, r = r, l
+		wipe.Bytes(f)
+	}
+	wipe.Bytes(roundSalt)
+	return append(r, l...)
+}
+
+// cipherSalt returns the salt of the Feistel cipher. For extendable shares, the salt is empty so that shares of
+// different identifiers can be created for the same encrypted master secret.
+func cipherSalt(identifier uint16, extendable bool) []byte {
+	if extendable {
+		return nil
+	}
+	salt := make([]byte, len(customizationString)+2)
+	copy(salt, customizationString)
+	binary.BigEndian.PutUint16(salt[len(customizationString):], identifier)
+	return salt
+}
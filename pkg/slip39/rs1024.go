@@ -0,0 +1,46 @@
+package slip39
+
+// The checksum is a Reed-Solomon code over GF(1024) that guarantees detection of any error affecting at most 3 words
+// and has less than a 1 in 10⁹ chance of failing to detect more errors.
+
+var rs1024Generator = [10]uint32{
+	0xe0e040, 0x1c1c080, 0x3838100, 0x7070200, 0xe0e0009, 0x1c0c2412, 0x38086c24, 0x3090fc48, 0x21b1f890, 0x3f3f120,
+}
+
+func rs1024Polymod(customization string, values []int) uint32 {
+	chk := uint32(1)
+	step := func(v uint32) {
+		b := chk >> 20
+		chk = (chk&0xfffff)<<10 ^ v
+		for i := range rs1024Generator {
+			if (b>>i)&1 != 0 {
+				chk ^= rs1024Generator[i]
+			}
+		}
+	}
+	for i := 0; i < len(customization); i++ {
+		step(uint32(customization[i]))
+	}
+	for _, v := range values {
+		step(uint32(v))
+	}
+	return chk
+}
+
+// rs1024CreateChecksum returns the three checksum words for data.
+func rs1024CreateChecksum(customization string, data []int) [checksumWords]int {
+	values := make([]int, len(data)+checksumWords)
+	copy(values, data)
+	polymod := rs1024Polymod(customization, values) ^ 1
+
+	var checksum [checksumWords]int
+	for i := range checksum {
+		checksum[i] = int(polymod>>(radixBits*(checksumWords-1-i))) & (radix - 1)
+	}
+	return checksum
+}
+
+// rs1024VerifyChecksum reports whether data ends with a valid checksum.
+func rs1024VerifyChecksum(customization string, data []int) bool {
+	return rs1024Polymod(customization, data) == 1
+}
@@ -0,0 +1,136 @@
+package slip39
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"github.com/wollac/iota-crypto-demo/internal/wipe"
+)
+
+const (
+	// x-coordinate of the shared secret
+	secretIndex = 255
+	// x-coordinate of the digest of the shared secret
+	digestIndex = 254
+	// length, in bytes, of the digest of the shared secret
+	digestLength = 4
+)
+
+// rawShare is a point (x, y) of the polynomials over GF(256), where y contains the value of one polynomial per byte.
+type rawShare struct {
+	x int
+	y []byte
+}
+
+// gfMul returns the product of a and b in GF(256) with the Rijndael polynomial x⁸ + x⁴ + x³ + x + 1.
+// It runs in constant time.
+func gfMul(a, b byte) byte {
+	var p byte
+	for i := 0; i < 8; i++ {
+		p ^= -(b & 1) & a
+		// multiply a by x and reduce, if the degree would exceed 7
+		a = a<<1 ^ -(a>>7)&0x1b
+		b >>= 1
+	}
+	return p
+}
+
+// gfInv returns the multiplicative inverse of a in GF(256), i.e. a²⁵⁴. The inverse of 0 is 0.
+func gfInv(a byte) byte {
+	// a²⁵⁴ = a² ⋅ a⁴ ⋅ … ⋅ a¹²⁸
+	var r byte = 1
+	for i := 0; i < 7; i++ {
+		a = gfMul(a, a)
+		r = gfMul(r, a)
+	}
+	return r
+}
+
+// interpolate returns the value at x of the polynomials of degree len(shares)-1 passing through the given shares
+// using Lagrange interpolation. All x-coordinates must be distinct and all values must have the same length.
+func interpolate(shares []rawShare, x int) []byte {
+	result := make([]byte, len(shares[0].y))
+	for i, si := range shares {
+		// Lagrange basis polynomial lᵢ(x) = ∏ (x - xⱼ) / (xᵢ - xⱼ), where subtraction is the XOR in GF(256)
+		var num, den byte = 1, 1
+		for j, sj := range shares {
+			if i != j {
+				num = gfMul(num, byte(x^sj.x))
+				den = gfMul(den, byte(si.x^sj.x))
+			}
+		}
+		basis := gfMul(num, gfInv(den))
+		for k := range result {
+			result[k] ^= gfMul(basis, si.y[k])
+		}
+	}
+	return result
+}
+
+// digest returns the first digestLength bytes of HMAC-SHA256(randomPart, secret).
+func digest(randomPart, secret []byte) []byte {
+	mac := hmac.New(sha256.New, randomPart)
+	mac.Write(secret)
+	return mac.Sum(nil)[:digestLength]
+}
+
+// splitSecret splits secret into count shares so that any threshold of them can recover it.
+func splitSecret(rand io.Reader, threshold, count int, secret []byte) ([]rawShare, error) {
+	if threshold < 1 || threshold > count || count > maxShareCount {
+		return nil, fmt.Errorf("%w: threshold %d of %d shares", ErrInvalidGroups, threshold, count)
+	}
+
+	shares := make([]rawShare, 0, count)
+	// if the threshold is 1, the digest of the shared secret is not used
+	if threshold == 1 {
+		for i := 0; i < count; i++ {
+			shares = append(shares, rawShare{i, append([]byte{}, secret...)})
+		}
+		return shares, nil
+	}
+
+	randomShareCount := threshold - 2
+	for i := 0; i < randomShareCount; i++ {
+		y := make([]byte, len(secret))
+		if _, err := io.ReadFull(rand, y); err != nil {
+			return nil, err
+		}
+		shares = append(shares, rawShare{i, y})
+	}
+
+	randomPart := make([]byte, len(secret)-digestLength)
+	if _, err := io.ReadFull(rand, randomPart); err != nil {
+		return nil, err
+	}
+	digestShare := append(digest(randomPart, secret), randomPart...)
+
+	baseShares := append(shares[:randomShareCount:randomShareCount],
+		rawShare{digestIndex, digestShare},
+		rawShare{secretIndex, secret},
+	)
+	for i := randomShareCount; i < count; i++ {
+		shares = append(shares, rawShare{i, interpolate(baseShares, i)})
+	}
+	wipe.Bytes(digestShare)
+	return shares, nil
+}
+
+// recoverSecret recovers the shared secret from threshold shares and verifies its digest.
+func recoverSecret(threshold int, shares []rawShare) ([]byte, error) {
+	// if the threshold is 1, the value of every share is the shared secret
+	if threshold == 1 {
+		return append([]byte{}, shares[0].y...), nil
+	}
+
+	secret := interpolate(shares, secretIndex)
+	digestShare := interpolate(shares, digestIndex)
+	defer wipe.Bytes(digestShare)
+
+	if !hmac.Equal(digestShare[:digestLength], digest(digestShare[digestLength:], secret)) {
+		wipe.Bytes(secret)
+		return nil, ErrInvalidDigest
+	}
+	return secret, nil
+}
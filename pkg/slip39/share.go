@@ -0,0 +1,201 @@
+package slip39
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// number of bits per word
+	radixBits = 10
+	// number of words in the word list
+	radix = 1 << radixBits
+	// number of words of the share header, i.e. identifier, extendable flag, iteration exponent, group and member data
+	headerWords = 4
+	// number of words of the checksum
+	checksumWords = 3
+	// maximum number of groups and maximum number of shares per group
+	maxShareCount = 16
+	// maximum value of the iteration exponent
+	maxIterationExponent = 1<<4 - 1
+
+	// customization strings of the checksum and the cipher
+	customizationString           = "shamir"
+	customizationStringExtendable = "shamir_extendable"
+)
+
+// minMnemonicWords is the number of words of a share containing a master secret of minimum length.
+const minMnemonicWords = headerWords + (8*MinSecretSize+radixBits-1)/radixBits + checksumWords
+
+// Mnemonic is a slice of words representing a single SLIP-0039 share.
+type Mnemonic []string
+
+// ParseMnemonic parses s as white space separated list of mnemonic words. Words are converted to lower case.
+func ParseMnemonic(s string) Mnemonic {
+	return strings.Fields(strings.ToLower(s))
+}
+
+// String returns all the words composing the mnemonic as a single string of space separated words.
+func (m Mnemonic) String() string {
+	return strings.Join(m, " ")
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (m Mnemonic) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (m *Mnemonic) UnmarshalText(text []byte) error {
+	*m = ParseMnemonic(string(text))
+	return nil
+}
+
+// share contains the decoded data of a single SLIP-0039 mnemonic.
+type share struct {
+	identifier        uint16
+	extendable        bool
+	iterationExponent int
+	groupIndex        int
+	groupThreshold    int
+	groupCount        int
+	memberIndex       int
+	memberThreshold   int
+	value             []byte
+}
+
+// commonParametersEqual reports whether s and o belong to the same master secret.
+func (s *share) commonParametersEqual(o *share) bool {
+	return s.identifier == o.identifier && s.extendable == o.extendable &&
+		s.iterationExponent == o.iterationExponent && s.groupThreshold == o.groupThreshold &&
+		s.groupCount == o.groupCount
+}
+
+// groupParametersEqual reports whether s and o belong to the same group.
+func (s *share) groupParametersEqual(o *share) bool {
+	return s.commonParametersEqual(o) && s.groupIndex == o.groupIndex && s.memberThreshold == o.memberThreshold
+}
+
+func (s *share) customization() string {
+	if s.extendable {
+		return customizationStringExtendable
+	}
+	return customizationString
+}
+
+// mnemonic encodes the share as mnemonic.
+func (s *share) mnemonic() Mnemonic {
+	var ext int
+	if s.extendable {
+		ext = 1
+	}
+	// 15-bit identifier, 1-bit extendable flag, 4 bits for each of the following fields
+	header := uint64(s.identifier)<<25 | uint64(ext)<<24 | uint64(s.iterationExponent)<<20 |
+		uint64(s.groupIndex)<<16 | uint64(s.groupThreshold-1)<<12 | uint64(s.groupCount-1)<<8 |
+		uint64(s.memberIndex)<<4 | uint64(s.memberThreshold-1)
+
+	data := make([]int, 0, headerWords+len(s.value)+checksumWords)
+	for i := headerWords - 1; i >= 0; i-- {
+		data = append(data, int(header>>(radixBits*i))&(radix-1))
+	}
+	data = append(data, bytesToWords(s.value)...)
+	checksum := rs1024CreateChecksum(s.customization(), data)
+	data = append(data, checksum[:]...)
+
+	m := make(Mnemonic, len(data))
+	for i, idx := range data {
+		m[i] = wordList[idx]
+	}
+	return m
+}
+
+// decodeMnemonic decodes and validates a single mnemonic.
+func decodeMnemonic(m Mnemonic) (*share, error) {
+	if len(m) < minMnemonicWords {
+		return nil, fmt.Errorf("%w: mnemonic must contain at least %d words", ErrInvalidMnemonic, minMnemonicWords)
+	}
+	data := make([]int, len(m))
+	for i, w := range m {
+		idx, ok := wordIndex[w]
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid word %q", ErrInvalidMnemonic, w)
+		}
+		data[i] = idx
+	}
+
+	var header uint64
+	for _, idx := range data[:headerWords] {
+		header = header<<radixBits | uint64(idx)
+	}
+	s := &share{
+		identifier:        uint16(header >> 25),
+		extendable:        header>>24&1 == 1,
+		iterationExponent: int(header >> 20 & 0xf),
+		groupIndex:        int(header >> 16 & 0xf),
+		groupThreshold:    int(header>>12&0xf) + 1,
+		groupCount:        int(header>>8&0xf) + 1,
+		memberIndex:       int(header >> 4 & 0xf),
+		memberThreshold:   int(header&0xf) + 1,
+	}
+	if !rs1024VerifyChecksum(s.customization(), data) {
+		return nil, ErrInvalidChecksum
+	}
+	if s.groupThreshold > s.groupCount {
+		return nil, fmt.Errorf("%w: group threshold %d exceeds group count %d",
+			ErrInvalidMnemonic, s.groupThreshold, s.groupCount)
+	}
+
+	value, err := wordsToBytes(data[headerWords : len(data)-checksumWords])
+	if err != nil {
+		return nil, err
+	}
+	s.value = value
+	return s, nil
+}
+
+// bytesToWords converts b into 10-bit words. The most significant bits of the first word are padded with zeros.
+func bytesToWords(b []byte) []int {
+	words := make([]int, (8*len(b)+radixBits-1)/radixBits)
+	// start with the padding bits, which are all zero
+	var acc uint32
+	n := len(words)*radixBits - 8*len(b)
+	i := 0
+	for _, x := range b {
+		acc = acc<<8 | uint32(x)
+		n += 8
+		for n >= radixBits {
+			n -= radixBits
+			words[i] = int(acc>>n) & (radix - 1)
+			i++
+		}
+	}
+	return words
+}
+
+// wordsToBytes converts the 10-bit words into bytes. The length of the result is a multiple of 16 bits and the
+// padding bits must be zero.
+func wordsToBytes(words []int) ([]byte, error) {
+	padding := radixBits * len(words) % 16
+	if padding > 8 {
+		return nil, fmt.Errorf("%w: invalid mnemonic length", ErrInvalidMnemonic)
+	}
+	// as the padding is shorter than a word, it is contained in the most significant bits of the first word
+	if words[0]>>(radixBits-padding) != 0 {
+		return nil, fmt.Errorf("%w: invalid padding", ErrInvalidMnemonic)
+	}
+
+	b := make([]byte, (radixBits*len(words)-padding)/8)
+	var acc uint32
+	n := -padding
+	i := 0
+	for _, w := range words {
+		acc = acc<<radixBits | uint32(w)
+		n += radixBits
+		for n >= 8 {
+			n -= 8
+			b[i] = byte(acc >> n)
+			i++
+		}
+	}
+	return b, nil
+}
@@ -0,0 +1,221 @@
+/*
+Package slip39 implements the SLIP-0039 specification of Shamir's Secret-Sharing for mnemonic codes.
+
+A master secret is encrypted with a passphrase and split into groups of mnemonic shares using a two-level scheme:
+The master secret can be recovered from any groupThreshold groups, where each group in turn is recovered from
+MemberThreshold of its member shares. The recovered master secret can directly be used as the seed of
+slip10.NewMasterKey.
+
+Both the original and the extendable backup share format are supported. Shares with the extendable flag set do not
+use their identifier in the encryption, so that further share sets can be created for the same encrypted master
+secret.
+
+This package is tested against the test vectors of the SLIP-0039 reference implementation.
+*/
+package slip39
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/wollac/iota-crypto-demo/internal/wipe"
+)
+
+const (
+	// MinSecretSize is the minimum size, in bytes, of a master secret.
+	MinSecretSize = 16
+	// DefaultIterationExponent is the iteration exponent used by most implementations.
+	DefaultIterationExponent = 1
+)
+
+var (
+	// ErrInvalidSecretSize is returned when the master secret is shorter than 128 bits or of odd length.
+	ErrInvalidSecretSize = errors.New("invalid master secret size")
+	// ErrInvalidPassphrase is returned when the passphrase contains characters other than printable ASCII.
+	ErrInvalidPassphrase = errors.New("passphrase must only contain printable ASCII characters")
+	// ErrInvalidGroups is returned when the group configuration is invalid.
+	ErrInvalidGroups = errors.New("invalid group configuration")
+	// ErrInvalidMnemonic is returned when trying to use a malformed mnemonic.
+	ErrInvalidMnemonic = errors.New("invalid mnemonic")
+	// ErrInvalidChecksum is returned when the checksum of a mnemonic does not match.
+	ErrInvalidChecksum = errors.New("invalid checksum")
+	// ErrMismatchedShares is returned when the combined mnemonics do not belong to the same share set.
+	ErrMismatchedShares = errors.New("mismatched mnemonic shares")
+	// ErrInsufficientShares is returned when the number of mnemonics does not match the required thresholds.
+	ErrInsufficientShares = errors.New("wrong number of mnemonic shares")
+	// ErrInvalidDigest is returned when the digest of a recovered secret does not match.
+	ErrInvalidDigest = errors.New("invalid digest of the shared secret")
+)
+
+// Group describes a group of member shares of which MemberThreshold shares are required to recover the group.
+type Group struct {
+	MemberThreshold int
+	MemberCount     int
+}
+
+// GenerateMnemonics splits the master secret into mnemonic shares. The master secret is encrypted with the passphrase
+// using 10000⋅2^iterationExponent PBKDF2 iterations. The i-th element of the result contains the member mnemonics of
+// the i-th group, of which groupThreshold groups are required to recover the master secret.
+func GenerateMnemonics(groupThreshold int, groups []Group, masterSecret []byte, passphrase string, extendable bool,
+	iterationExponent int) ([][]Mnemonic, error) {
+	if err := validateSecret(masterSecret); err != nil {
+		return nil, err
+	}
+	if err := validatePassphrase(passphrase); err != nil {
+		return nil, err
+	}
+	if iterationExponent < 0 || iterationExponent > maxIterationExponent {
+		return nil, fmt.Errorf("%w: iteration exponent must be between 0 and %d", ErrInvalidGroups, maxIterationExponent)
+	}
+	if groupThreshold < 1 || groupThreshold > len(groups) || len(groups) > maxShareCount {
+		return nil, fmt.Errorf("%w: group threshold %d of %d groups", ErrInvalidGroups, groupThreshold, len(groups))
+	}
+	for _, g := range groups {
+		if g.MemberThreshold < 1 || g.MemberThreshold > g.MemberCount || g.MemberCount > maxShareCount {
+			return nil, fmt.Errorf("%w: member threshold %d of %d shares", ErrInvalidGroups, g.MemberThreshold, g.MemberCount)
+		}
+		if g.MemberThreshold == 1 && g.MemberCount > 1 {
+			return nil, fmt.Errorf("%w: use 1-of-1 member sharing instead of 1-of-%d", ErrInvalidGroups, g.MemberCount)
+		}
+	}
+
+	var buf [2]byte
+	if _, err := io.ReadFull(rand.Reader, buf[:]); err != nil {
+		return nil, err
+	}
+	identifier := binary.BigEndian.Uint16(buf[:]) >> 1 // 15-bit identifier
+
+	encryptedMasterSecret := encrypt(masterSecret, []byte(passphrase), iterationExponent, identifier, extendable)
+	defer wipe.Bytes(encryptedMasterSecret)
+	groupShares, err := splitSecret(rand.Reader, groupThreshold, len(groups), encryptedMasterSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([][]Mnemonic, len(groups))
+	for i, g := range groups {
+		memberShares, err := splitSecret(rand.Reader, g.MemberThreshold, g.MemberCount, groupShares[i].y)
+		if err != nil {
+			return nil, err
+		}
+		wipe.Bytes(groupShares[i].y)
+
+		for _, ms := range memberShares {
+			s := &share{
+				identifier:        identifier,
+				extendable:        extendable,
+				iterationExponent: iterationExponent,
+				groupIndex:        groupShares[i].x,
+				groupThreshold:    groupThreshold,
+				groupCount:        len(groups),
+				memberIndex:       ms.x,
+				memberThreshold:   g.MemberThreshold,
+				value:             ms.y,
+			}
+			result[i] = append(result[i], s.mnemonic())
+			wipe.Bytes(ms.y)
+		}
+	}
+	return result, nil
+}
+
+// CombineMnemonics recovers the master secret from the given mnemonic shares and decrypts it using the passphrase.
+// Exactly groupThreshold groups with exactly MemberThreshold member shares each must be provided.
+// As any passphrase leads to a valid master secret, a wrong passphrase cannot be detected.
+func CombineMnemonics(mnemonics []Mnemonic, passphrase string) ([]byte, error) {
+	if err := validatePassphrase(passphrase); err != nil {
+		return nil, err
+	}
+	if len(mnemonics) == 0 {
+		return nil, fmt.Errorf("%w: no mnemonics provided", ErrInsufficientShares)
+	}
+
+	shares := make([]*share, len(mnemonics))
+	for i := range mnemonics {
+		s, err := decodeMnemonic(mnemonics[i])
+		if err != nil {
+			return nil, err
+		}
+		shares[i] = s
+	}
+
+	encryptedMasterSecret, err := recoverEncryptedMasterSecret(shares)
+	if err != nil {
+		return nil, err
+	}
+	defer wipe.Bytes(encryptedMasterSecret)
+
+	s := shares[0]
+	return decrypt(encryptedMasterSecret, []byte(passphrase), s.iterationExponent, s.identifier, s.extendable), nil
+}
+
+func recoverEncryptedMasterSecret(shares []*share) ([]byte, error) {
+	first := shares[0]
+	// group the shares by their group index while preserving the order
+	var groupIndices []int
+	groups := map[int][]*share{}
+	for _, s := range shares {
+		if !s.commonParametersEqual(first) || len(s.value) != len(first.value) {
+			return nil, ErrMismatchedShares
+		}
+		group, ok := groups[s.groupIndex]
+		if !ok {
+			groupIndices = append(groupIndices, s.groupIndex)
+		} else if !s.groupParametersEqual(group[0]) {
+			return nil, fmt.Errorf("%w: member thresholds of group %d differ", ErrMismatchedShares, s.groupIndex)
+		}
+		for _, o := range group {
+			if o.memberIndex == s.memberIndex {
+				return nil, fmt.Errorf("%w: duplicate member index %d", ErrMismatchedShares, s.memberIndex)
+			}
+		}
+		groups[s.groupIndex] = append(group, s)
+	}
+
+	if len(groups) != first.groupThreshold {
+		return nil, fmt.Errorf("%w: expected %d groups, got %d", ErrInsufficientShares, first.groupThreshold, len(groups))
+	}
+
+	groupShares := make([]rawShare, 0, len(groups))
+	defer func() {
+		for _, s := range groupShares {
+			wipe.Bytes(s.y)
+		}
+	}()
+	for _, idx := range groupIndices {
+		group := groups[idx]
+		if len(group) != group[0].memberThreshold {
+			return nil, fmt.Errorf("%w: expected %d mnemonics for group %d, got %d",
+				ErrInsufficientShares, group[0].memberThreshold, idx, len(group))
+		}
+		memberShares := make([]rawShare, len(group))
+		for i, s := range group {
+			memberShares[i] = rawShare{s.memberIndex, s.value}
+		}
+		secret, err := recoverSecret(group[0].memberThreshold, memberShares)
+		if err != nil {
+			return nil, err
+		}
+		groupShares = append(groupShares, rawShare{idx, secret})
+	}
+	return recoverSecret(first.groupThreshold, groupShares)
+}
+
+func validateSecret(masterSecret []byte) error {
+	if len(masterSecret) < MinSecretSize || len(masterSecret)%2 != 0 {
+		return fmt.Errorf("%w: %d bytes", ErrInvalidSecretSize, len(masterSecret))
+	}
+	return nil
+}
+
+func validatePassphrase(passphrase string) error {
+	for i := 0; i < len(passphrase); i++ {
+		if passphrase[i] < 32 || passphrase[i] > 126 {
+			return ErrInvalidPassphrase
+		}
+	}
+	return nil
+}
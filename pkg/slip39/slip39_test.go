@@ -0,0 +1,168 @@
+package slip39_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wollac/iota-crypto-demo/internal/hexutil"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10"
+	"github.com/wollac/iota-crypto-demo/pkg/slip10/elliptic"
+	"github.com/wollac/iota-crypto-demo/pkg/slip39"
+)
+
+// passphrase used in the official test vectors
+const passphrase = "TREZOR"
+
+type Test struct {
+	Description string            `json:"description"`
+	Mnemonics   []slip39.Mnemonic `json:"mnemonics"`
+	Secret      hexutil.Bytes     `json:"secret"`
+	Xprv        string            `json:"xprv"`
+}
+
+// TestSLIP39 uses the test vectors of the SLIP-0039 reference implementation, see
+// https://github.com/trezor/python-shamir-mnemonic/blob/master/vectors.json.
+// Vectors 9 and 27 are not included, as one of their mnemonics could not be reproduced with a valid checksum.
+func TestSLIP39(t *testing.T) {
+	runJSONTests(t)
+}
+
+func runJSONTests(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("testdata", t.Name()+".json"))
+	require.NoError(t, err)
+	var tests []Test
+	require.NoError(t, json.Unmarshal(b, &tests))
+
+	for _, tt := range tests {
+		t.Run(tt.Description, func(t *testing.T) {
+			secret, err := slip39.CombineMnemonics(tt.Mnemonics, passphrase)
+			if len(tt.Secret) == 0 {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.EqualValues(t, tt.Secret, secret)
+
+			// the master secret is the seed of the BIP-32 master key
+			key, err := slip10.NewMasterKey(secret, elliptic.Secp256k1())
+			require.NoError(t, err)
+			assert.Equal(t, tt.Xprv, key.String())
+		})
+	}
+}
+
+func TestGenerateMnemonics(t *testing.T) {
+	masterSecret := []byte("ABCDEFGHIJKLMNOP")
+	var tests = []*struct {
+		name           string
+		groupThreshold int
+		groups         []slip39.Group
+		// indices of the mnemonics used for the recovery per group
+		recover map[int][]int
+	}{
+		{"1-of-1", 1, []slip39.Group{{1, 1}}, map[int][]int{0: {0}}},
+		{"3-of-5", 1, []slip39.Group{{3, 5}}, map[int][]int{0: {4, 0, 2}}},
+		{"5-of-5", 1, []slip39.Group{{5, 5}}, map[int][]int{0: {0, 1, 2, 3, 4}}},
+		{"groups 1-of-2", 1, []slip39.Group{{1, 1}, {2, 3}}, map[int][]int{1: {1, 2}}},
+		{"groups 2-of-4", 2, []slip39.Group{{1, 1}, {1, 1}, {2, 5}, {3, 6}}, map[int][]int{0: {0}, 3: {5, 1, 3}}},
+	}
+	for _, extendable := range []bool{false, true} {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				groups, err := slip39.GenerateMnemonics(tt.groupThreshold, tt.groups, masterSecret, passphrase, extendable, 0)
+				require.NoError(t, err)
+				require.Len(t, groups, len(tt.groups))
+				for i, g := range tt.groups {
+					assert.Len(t, groups[i], g.MemberCount)
+				}
+
+				var mnemonics []slip39.Mnemonic
+				for group, members := range tt.recover {
+					for _, member := range members {
+						mnemonics = append(mnemonics, groups[group][member])
+					}
+				}
+				secret, err := slip39.CombineMnemonics(mnemonics, passphrase)
+				require.NoError(t, err)
+				assert.Equal(t, masterSecret, secret)
+
+				// a different passphrase leads to a different master secret
+				secret, err = slip39.CombineMnemonics(mnemonics, "")
+				require.NoError(t, err)
+				assert.NotEqual(t, masterSecret, secret)
+			})
+		}
+	}
+}
+
+func TestGenerateMnemonicsInvalid(t *testing.T) {
+	masterSecret := make([]byte, slip39.MinSecretSize)
+	var tests = []*struct {
+		name           string
+		groupThreshold int
+		groups         []slip39.Group
+		masterSecret   []byte
+		passphrase     string
+		err            error
+	}{
+		{"short secret", 1, []slip39.Group{{1, 1}}, make([]byte, 14), "", slip39.ErrInvalidSecretSize},
+		{"odd secret", 1, []slip39.Group{{1, 1}}, make([]byte, 17), "", slip39.ErrInvalidSecretSize},
+		{"passphrase", 1, []slip39.Group{{1, 1}}, masterSecret, "pass\nphrase", slip39.ErrInvalidPassphrase},
+		{"no groups", 1, nil, masterSecret, "", slip39.ErrInvalidGroups},
+		{"group threshold", 3, []slip39.Group{{1, 1}, {1, 1}}, masterSecret, "", slip39.ErrInvalidGroups},
+		{"member threshold", 1, []slip39.Group{{3, 2}}, masterSecret, "", slip39.ErrInvalidGroups},
+		{"member count", 1, []slip39.Group{{2, 17}}, masterSecret, "", slip39.ErrInvalidGroups},
+		{"1-of-2 members", 1, []slip39.Group{{1, 2}}, masterSecret, "", slip39.ErrInvalidGroups},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := slip39.GenerateMnemonics(tt.groupThreshold, tt.groups, tt.masterSecret, tt.passphrase, false, 0)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestCombineMnemonicsInvalid(t *testing.T) {
+	masterSecret := []byte("ABCDEFGHIJKLMNOP")
+	groups := []slip39.Group{{2, 3}, {2, 3}, {1, 1}}
+	a, err := slip39.GenerateMnemonics(2, groups, masterSecret, "", false, 0)
+	require.NoError(t, err)
+	b, err := slip39.GenerateMnemonics(2, groups, masterSecret, "", false, 0)
+	require.NoError(t, err)
+
+	var tests = []*struct {
+		name      string
+		mnemonics []slip39.Mnemonic
+		err       error
+	}{
+		{"no mnemonics", nil, slip39.ErrInsufficientShares},
+		{"different identifiers", []slip39.Mnemonic{a[2][0], b[0][0], b[0][1]}, slip39.ErrMismatchedShares},
+		{"duplicate member", []slip39.Mnemonic{a[2][0], a[0][0], a[0][0]}, slip39.ErrMismatchedShares},
+		{"insufficient groups", []slip39.Mnemonic{a[0][0], a[0][1]}, slip39.ErrInsufficientShares},
+		{"too many groups", []slip39.Mnemonic{a[2][0], a[0][0], a[0][1], a[1][0], a[1][1]}, slip39.ErrInsufficientShares},
+		{"insufficient members", []slip39.Mnemonic{a[2][0], a[0][0]}, slip39.ErrInsufficientShares},
+		{"too many members", []slip39.Mnemonic{a[2][0], a[0][0], a[0][1], a[0][2]}, slip39.ErrInsufficientShares},
+		{"invalid word", []slip39.Mnemonic{append(slip39.Mnemonic{"foo"}, a[2][0][1:]...)}, slip39.ErrInvalidMnemonic},
+		{"too short", []slip39.Mnemonic{a[2][0][:19]}, slip39.ErrInvalidMnemonic},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := slip39.CombineMnemonics(tt.mnemonics, "")
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestMnemonicText(t *testing.T) {
+	const s = "duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision keyboard"
+
+	var m slip39.Mnemonic
+	require.NoError(t, m.UnmarshalText([]byte("  DUCKLING  "+s[len("duckling "):]+"\n")))
+	text, err := m.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, s, string(text))
+}
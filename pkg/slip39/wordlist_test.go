@@ -0,0 +1,21 @@
+package slip39
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordList(t *testing.T) {
+	assert.Len(t, wordList, radix)
+	assert.True(t, sort.StringsAreSorted(wordList))
+
+	prefixes := map[string]bool{}
+	for _, w := range wordList {
+		assert.GreaterOrEqual(t, len(w), 4)
+		assert.LessOrEqual(t, len(w), 8)
+		assert.Falsef(t, prefixes[w[:4]], "duplicate prefix %s", w[:4])
+		prefixes[w[:4]] = true
+	}
+}